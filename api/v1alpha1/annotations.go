@@ -1,6 +1,12 @@
 package v1alpha1
 
-import "strconv"
+import (
+	"strconv"
+	"time"
+)
+
+// AnnotationPrefix is the prefix of every hasteward annotation key.
+const AnnotationPrefix = "clinic.hasteward.prplanit.com/"
 
 // Annotation keys for database CR opt-in and overrides.
 const (
@@ -13,12 +19,25 @@ const (
 	// AnnotationTriageSchedule overrides the triage cron schedule.
 	AnnotationTriageSchedule = "clinic.hasteward.prplanit.com/triage-schedule"
 
+	// AnnotationPruneSchedule overrides the retention (prune) cron schedule.
+	AnnotationPruneSchedule = "clinic.hasteward.prplanit.com/prune-schedule"
+
+	// AnnotationStartingDeadline overrides the catch-up starting deadline in
+	// seconds (0 disables catch-up of missed backups).
+	AnnotationStartingDeadline = "clinic.hasteward.prplanit.com/starting-deadline-seconds"
+
+	// AnnotationRPO overrides the recovery point objective (Go duration).
+	AnnotationRPO = "clinic.hasteward.prplanit.com/rpo"
+
 	// AnnotationMode overrides the operation mode (triage, repair, disabled).
 	AnnotationMode = "clinic.hasteward.prplanit.com/mode"
 
 	// AnnotationRepositories overrides target repositories (comma-separated).
 	AnnotationRepositories = "clinic.hasteward.prplanit.com/repositories"
 
+	// AnnotationNotificationChannels overrides notification channels (comma-separated).
+	AnnotationNotificationChannels = "clinic.hasteward.prplanit.com/notification-channels"
+
 	// AnnotationRetentionKeepLast overrides retention keep-last.
 	AnnotationRetentionKeepLast = "clinic.hasteward.prplanit.com/retention-keep-last"
 
@@ -39,30 +58,82 @@ const (
 
 	// AnnotationDeleteTimeout overrides the delete timeout in seconds.
 	AnnotationDeleteTimeout = "clinic.hasteward.prplanit.com/delete-timeout"
+
+	// AnnotationMaintenanceWindows overrides the maintenance windows
+	// ("schedule|duration", semicolon-separated; empty allows any time).
+	AnnotationMaintenanceWindows = "clinic.hasteward.prplanit.com/maintenance-windows"
+
+	// AnnotationBlackoutWindows overrides the backup blackout windows
+	// ("schedule|duration", semicolon-separated; empty removes them).
+	AnnotationBlackoutWindows = "clinic.hasteward.prplanit.com/blackout-windows"
 )
 
-// Status annotations written by hasteward (read-only for users).
+// Status annotations written by hasteward before ManagedDatabase existed.
+//
+// Deprecated: the operator records status on the ManagedDatabase CR and no
+// longer writes these. `hasteward get status` still reads them for databases
+// that have no ManagedDatabase yet. AnnotationManaged is still written.
 const (
 	AnnotationLastBackup         = "clinic.hasteward.prplanit.com/last-backup"
 	AnnotationLastBackupDuration = "clinic.hasteward.prplanit.com/last-backup-duration"
 	AnnotationLastTriage         = "clinic.hasteward.prplanit.com/last-triage"
 	AnnotationLastTriageResult   = "clinic.hasteward.prplanit.com/last-triage-result"
 	AnnotationLastRepair         = "clinic.hasteward.prplanit.com/last-repair"
-	AnnotationManaged            = "clinic.hasteward.prplanit.com/managed"
+	AnnotationLastPrune          = "clinic.hasteward.prplanit.com/last-prune"
+	AnnotationLastPruneResult    = "clinic.hasteward.prplanit.com/last-prune-result"
 )
 
+// AnnotationManaged is set to "true" on database CRs the operator manages.
+const AnnotationManaged = "clinic.hasteward.prplanit.com/managed"
+
+// AnnotationResetRepairCircuit, set on a ManagedDatabase, closes an open
+// auto-repair circuit and clears the failure backoff. The operator removes it
+// once applied.
+const AnnotationResetRepairCircuit = "clinic.hasteward.prplanit.com/reset-repair-circuit"
+
+// AnnotationApprovedBy approves a RestoreRequest or RepairProposal; the value
+// names the approver. The admission webhook replaces the value with the
+// authenticated user who added it, who must not be the requester. Without the
+// webhook it is never honoured.
+const AnnotationApprovedBy = "clinic.hasteward.prplanit.com/approved-by"
+
+// AnnotationRequestedBy records the authenticated user who created a
+// RestoreRequest or RepairProposal. The admission webhook sets it on create and keeps it from
+// changing; any value supplied by the client is overwritten.
+const AnnotationRequestedBy = "clinic.hasteward.prplanit.com/requested-by"
+
 // EffectiveConfig is the resolved configuration for a managed database,
 // merging BackupPolicy defaults with per-CR annotation overrides.
+// It is also recorded in ManagedDatabaseStatus.
 type EffectiveConfig struct {
-	PolicyName     string
-	BackupSchedule string
-	TriageSchedule string
-	Mode           string
-	Repositories   []string
-	Retention      RetentionPolicy
-	HealTimeout    int
-	DeleteTimeout  int
-	Excluded       bool
+	PolicyName     string          `json:"policyName,omitempty"`
+	BackupSchedule string          `json:"backupSchedule,omitempty"`
+	TriageSchedule string          `json:"triageSchedule,omitempty"`
+	PruneSchedule  string          `json:"pruneSchedule,omitempty"`
+	Mode           string          `json:"mode,omitempty"`
+	Repositories   []string        `json:"repositories,omitempty"`
+	Retention      RetentionPolicy `json:"retention,omitempty"`
+	HealTimeout    int             `json:"healTimeout,omitempty"`
+	DeleteTimeout  int             `json:"deleteTimeout,omitempty"`
+	Excluded       bool            `json:"excluded,omitempty"`
+
+	// StartingDeadlineSeconds is nil when missed backups are always caught up.
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// RPO is empty when no recovery point objective applies.
+	RPO string `json:"rpo,omitempty"`
+
+	// PolicyBinding is "annotation" when the policy was named by the policy
+	// annotation and "selector" when a policy's spec.selector matched.
+	PolicyBinding string `json:"policyBinding,omitempty"`
+
+	MaintenanceWindows []TimeWindow `json:"maintenanceWindows,omitempty"`
+	BlackoutWindows    []TimeWindow `json:"blackoutWindows,omitempty"`
+
+	// RepairLimits is nil when the default auto-repair limits apply.
+	RepairLimits *RepairLimits `json:"repairLimits,omitempty"`
+
+	NotificationChannels []string `json:"notificationChannels,omitempty"`
 }
 
 // ParseAnnotations resolves the effective configuration for a database CR
@@ -75,11 +146,18 @@ func ParseAnnotations(annotations map[string]string, policy *BackupPolicySpec) *
 	if policy != nil {
 		cfg.BackupSchedule = policy.BackupSchedule
 		cfg.TriageSchedule = policy.TriageSchedule
+		cfg.PruneSchedule = policy.PruneSchedule
+		cfg.StartingDeadlineSeconds = policy.StartingDeadlineSeconds
+		cfg.RPO = policy.RPO
 		cfg.Mode = policy.Mode
 		cfg.Repositories = policy.Repositories
 		cfg.Retention = policy.Retention
 		cfg.HealTimeout = policy.HealTimeout
 		cfg.DeleteTimeout = policy.DeleteTimeout
+		cfg.MaintenanceWindows = policy.MaintenanceWindows
+		cfg.BlackoutWindows = policy.BlackoutWindows
+		cfg.RepairLimits = policy.RepairLimits
+		cfg.NotificationChannels = policy.NotificationChannels
 	}
 
 	// Apply annotation overrides
@@ -89,12 +167,38 @@ func ParseAnnotations(annotations map[string]string, policy *BackupPolicySpec) *
 	if v, ok := annotations[AnnotationTriageSchedule]; ok {
 		cfg.TriageSchedule = v
 	}
+	if v, ok := annotations[AnnotationPruneSchedule]; ok {
+		cfg.PruneSchedule = v
+	}
+	if v, ok := annotations[AnnotationStartingDeadline]; ok {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n >= 0 {
+			cfg.StartingDeadlineSeconds = &n
+		}
+	}
+	if v, ok := annotations[AnnotationRPO]; ok {
+		if _, err := time.ParseDuration(v); err == nil || v == "" {
+			cfg.RPO = v
+		}
+	}
 	if v, ok := annotations[AnnotationMode]; ok {
 		cfg.Mode = v
 	}
 	if v, ok := annotations[AnnotationRepositories]; ok {
 		cfg.Repositories = splitCSV(v)
 	}
+	if v, ok := annotations[AnnotationNotificationChannels]; ok {
+		cfg.NotificationChannels = splitCSV(v)
+	}
+	if v, ok := annotations[AnnotationMaintenanceWindows]; ok {
+		if windows, err := ParseWindows(v); err == nil {
+			cfg.MaintenanceWindows = windows
+		}
+	}
+	if v, ok := annotations[AnnotationBlackoutWindows]; ok {
+		if windows, err := ParseWindows(v); err == nil {
+			cfg.BlackoutWindows = windows
+		}
+	}
 	if v, ok := annotations[AnnotationRetentionKeepLast]; ok {
 		if n, err := strconv.Atoi(v); err == nil {
 			cfg.Retention.KeepLast = n
@@ -25,6 +25,20 @@ type BackupPolicySpec struct {
 	// Retention defines the restic snapshot retention policy.
 	Retention RetentionPolicy `json:"retention,omitempty"`
 
+	// PruneSchedule is a cron expression for retention enforcement.
+	// When empty, retention runs after each successful backup instead.
+	PruneSchedule string `json:"pruneSchedule,omitempty"`
+
+	// StartingDeadlineSeconds bounds how late a missed backup may still be
+	// caught up when the operator starts managing a database, like a
+	// CronJob's startingDeadlineSeconds. Unset means always catch up; 0
+	// disables catch-up.
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// RPO is the recovery point objective as a Go duration (e.g. "26h"): the
+	// oldest a database's last successful backup to each repository may be.
+	RPO string `json:"rpo,omitempty"`
+
 	// Repositories lists BackupRepository names to target.
 	Repositories []string `json:"repositories,omitempty"`
 
@@ -33,6 +47,70 @@ type BackupPolicySpec struct {
 
 	// DeleteTimeout is the delete operation timeout in seconds.
 	DeleteTimeout int `json:"deleteTimeout,omitempty"`
+
+	// Selector binds matching databases to this policy without a policy
+	// annotation. Nil binds nothing.
+	Selector *PolicySelector `json:"selector,omitempty"`
+
+	// MaintenanceWindows are the only times auto-repair may run. Empty
+	// allows repair at any time.
+	MaintenanceWindows []TimeWindow `json:"maintenanceWindows,omitempty"`
+
+	// BlackoutWindows are times scheduled backups must not run; a backup
+	// that fires inside one is deferred until it ends.
+	BlackoutWindows []TimeWindow `json:"blackoutWindows,omitempty"`
+
+	// RepairLimits rate-limits auto-repair per database. Nil uses the
+	// defaults of each field.
+	RepairLimits *RepairLimits `json:"repairLimits,omitempty"`
+
+	// NotificationChannels lists NotificationChannel names that receive
+	// this policy's databases' events.
+	NotificationChannels []string `json:"notificationChannels,omitempty"`
+}
+
+// RepairLimits bounds how often auto-repair may run against one database.
+// Zero values use the defaults.
+type RepairLimits struct {
+	// MaxRepairs is the number of auto-repairs allowed per Window
+	// (default 3).
+	MaxRepairs int `json:"maxRepairs,omitempty"`
+
+	// Window is the sliding window for MaxRepairs as a Go duration
+	// (default "24h").
+	Window string `json:"window,omitempty"`
+
+	// Backoff is the wait after a failed repair, doubled for each further
+	// consecutive failure (default "15m").
+	Backoff string `json:"backoff,omitempty"`
+
+	// MaxBackoff caps the backoff (default "6h").
+	MaxBackoff string `json:"maxBackoff,omitempty"`
+
+	// FailureThreshold is the number of consecutive failed repairs that
+	// opens the circuit, stopping auto-repair until it is reset (default 3).
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+}
+
+// TimeWindow is a recurring window opening on a cron schedule and lasting
+// for a duration.
+type TimeWindow struct {
+	// Schedule is a 6-field cron expression (with seconds) for the start.
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the window stays open, as a Go duration.
+	Duration string `json:"duration"`
+}
+
+// PolicySelector selects the database CRs a policy applies to. Both selectors
+// must match; an omitted or empty selector matches everything.
+type PolicySelector struct {
+	// NamespaceSelector matches labels on the database's namespace. Ignored
+	// on NamespacedBackupPolicy, which only selects in its own namespace.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// ObjectSelector matches labels on the CNPG Cluster or MariaDB CR.
+	ObjectSelector *metav1.LabelSelector `json:"objectSelector,omitempty"`
 }
 
 // RetentionPolicy defines how many restic snapshots to keep.
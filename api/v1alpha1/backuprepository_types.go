@@ -15,6 +15,36 @@ type BackupRepository struct {
 // BackupRepositorySpec defines the desired state of a backup repository.
 type BackupRepositorySpec struct {
 	Restic ResticSpec `json:"restic"`
+
+	// CheckSchedule is a cron expression for restic integrity checks.
+	// Empty disables scheduled checks.
+	CheckSchedule string `json:"checkSchedule,omitempty"`
+
+	// CheckReadDataSubset is passed to restic check --read-data-subset
+	// (e.g. "5%", "1/7", "500M") to verify a sample of pack data on each check.
+	// Empty checks repository structure only.
+	CheckReadDataSubset string `json:"checkReadDataSubset,omitempty"`
+
+	// MaxConcurrency caps scheduled backups writing to this repository at
+	// once. Zero uses the operator default (--repository-concurrency).
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+
+	// AllowedNamespaces lists the namespaces whose NamespacedBackupPolicies
+	// may reference this repository; "*" allows all. Empty allows none.
+	// Cluster-scoped BackupPolicies are not restricted. Ignored on
+	// NamespacedBackupRepository.
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
+// AllowsNamespace reports whether a NamespacedBackupPolicy in namespace may
+// reference the repository.
+func (s *BackupRepositorySpec) AllowsNamespace(namespace string) bool {
+	for _, ns := range s.AllowedNamespaces {
+		if ns == "*" || ns == namespace {
+			return true
+		}
+	}
+	return false
 }
 
 // ResticSpec defines the restic repository connection details.
@@ -46,12 +76,19 @@ type SecretRef struct {
 
 // BackupRepositoryStatus defines the observed state of a backup repository.
 type BackupRepositoryStatus struct {
-	Ready             bool        `json:"ready"`
-	LastCheck         metav1.Time `json:"lastCheck,omitempty"`
-	SnapshotCount     int         `json:"snapshotCount,omitempty"`
-	TotalSize         string      `json:"totalSize,omitempty"`
-	DeduplicatedSize  string      `json:"deduplicatedSize,omitempty"`
-	LastError         string      `json:"lastError,omitempty"`
+	Ready bool `json:"ready"`
+	// LastProbe is when the repository was last opened to refresh the
+	// snapshot and size statistics.
+	LastProbe        metav1.Time `json:"lastProbe,omitempty"`
+	SnapshotCount    int         `json:"snapshotCount,omitempty"`
+	TotalSize        string      `json:"totalSize,omitempty"`
+	DeduplicatedSize string      `json:"deduplicatedSize,omitempty"`
+	LastError        string      `json:"lastError,omitempty"`
+
+	// LastCheck is when the last scheduled restic check finished.
+	LastCheck metav1.Time `json:"lastCheck,omitempty"`
+	// IntegrityCheckResult is "passed" or "failed" for the last restic check.
+	IntegrityCheckResult string `json:"integrityCheckResult,omitempty"`
 }
 
 // BackupRepositoryList contains a list of BackupRepository resources.
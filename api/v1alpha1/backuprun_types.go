@@ -0,0 +1,74 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// BackupRun phases.
+const (
+	BackupRunPending   = "Pending"
+	BackupRunRunning   = "Running"
+	BackupRunSucceeded = "Succeeded"
+	BackupRunFailed    = "Failed"
+)
+
+// BackupRun requests a one-off backup of a managed database. Namespaced, in
+// the database's namespace. The operator runs it once through the database's
+// job queue using the repository credentials it already holds, so creating a
+// BackupRun needs no access to restic passwords. The spec is immutable.
+type BackupRun struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackupRunSpec   `json:"spec,omitempty"`
+	Status BackupRunStatus `json:"status,omitempty"`
+}
+
+// BackupRunSpec identifies the database and what to back up.
+type BackupRunSpec struct {
+	// Engine is "cnpg" or "galera".
+	Engine string `json:"engine"`
+
+	// ClusterName is the name of the CNPG Cluster or MariaDB CR.
+	ClusterName string `json:"clusterName"`
+
+	// Repositories limits the run to these BackupRepositories, which must be
+	// among the database's effective repositories. Empty means all of them.
+	Repositories []string `json:"repositories,omitempty"`
+
+	// Method is "dump" (default) or "native" (CNPG barmanObjectStore only;
+	// repositories are ignored).
+	Method string `json:"method,omitempty"`
+}
+
+// BackupRunStatus is the progress and outcome written by the operator.
+type BackupRunStatus struct {
+	// Phase is Pending, Running, Succeeded or Failed.
+	Phase string `json:"phase,omitempty"`
+
+	StartTime      *metav1.Time `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	Duration       string       `json:"duration,omitempty"`
+
+	// Results holds one entry per repository, appended as each finishes.
+	Results []BackupRunResult `json:"results,omitempty"`
+
+	// Error explains a Failed phase.
+	Error string `json:"error,omitempty"`
+}
+
+// BackupRunResult is the outcome of the backup to one repository.
+type BackupRunResult struct {
+	// Repository is the BackupRepository name, or the object store
+	// destination for native backups.
+	Repository string `json:"repository"`
+	SnapshotID string `json:"snapshotID,omitempty"`
+	SizeBytes  int64  `json:"sizeBytes,omitempty"`
+	Duration   string `json:"duration,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// BackupRunList contains a list of BackupRun resources.
+type BackupRunList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackupRun `json:"items"`
+}
@@ -23,6 +23,20 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&BackupRepositoryList{},
 		&BackupPolicy{},
 		&BackupPolicyList{},
+		&ManagedDatabase{},
+		&ManagedDatabaseList{},
+		&BackupRun{},
+		&BackupRunList{},
+		&RestoreRequest{},
+		&RestoreRequestList{},
+		&RepairProposal{},
+		&RepairProposalList{},
+		&NamespacedBackupPolicy{},
+		&NamespacedBackupPolicyList{},
+		&NamespacedBackupRepository{},
+		&NamespacedBackupRepositoryList{},
+		&NotificationChannel{},
+		&NotificationChannelList{},
 	)
 	metav1.AddToGroupVersion(scheme, GroupVersion)
 	return nil
@@ -0,0 +1,192 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// MaxRecordedSnapshots is how many recent snapshots are kept per repository
+// in ManagedDatabaseStatus.Backups.
+const MaxRecordedSnapshots = 5
+
+// MaxTriageHistory is how many triage results are kept in
+// ManagedDatabaseStatus.TriageHistory.
+const MaxTriageHistory = 20
+
+// ManagedDatabase records hasteward's view of one opted-in database CR.
+// Namespaced, created and owned by the operator alongside the CNPG Cluster or
+// MariaDB CR it describes (named <engine>-<cluster>). Users read it; the
+// operator writes it.
+type ManagedDatabase struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ManagedDatabaseSpec   `json:"spec,omitempty"`
+	Status ManagedDatabaseStatus `json:"status,omitempty"`
+}
+
+// ManagedDatabaseSpec identifies the database CR in the same namespace.
+type ManagedDatabaseSpec struct {
+	// Engine is "cnpg" or "galera".
+	Engine string `json:"engine"`
+
+	// ClusterName is the name of the CNPG Cluster or MariaDB CR.
+	ClusterName string `json:"clusterName"`
+}
+
+// ManagedDatabaseStatus is the observed state written by the operator.
+type ManagedDatabaseStatus struct {
+	// EffectiveConfig is the BackupPolicy merged with annotation overrides.
+	EffectiveConfig EffectiveConfig `json:"effectiveConfig,omitempty"`
+
+	// Backups holds the latest backup outcome per repository.
+	Backups []RepositoryBackupStatus `json:"backups,omitempty"`
+
+	// Triage is the result of the last scheduled triage.
+	Triage *TriageStatus `json:"triage,omitempty"`
+
+	// TriageHistory lists recent scheduled triage results, newest first,
+	// capped at MaxTriageHistory.
+	TriageHistory []TriageSnapshot `json:"triageHistory,omitempty"`
+
+	// Repair is the result of the last auto-repair.
+	Repair *RepairStatus `json:"repair,omitempty"`
+
+	// RepairGuard is the auto-repair rate limit and circuit breaker state.
+	RepairGuard *RepairGuardStatus `json:"repairGuard,omitempty"`
+
+	// Prune is the result of the last retention run.
+	Prune *PruneStatus `json:"prune,omitempty"`
+
+	// NextRuns lists when each scheduled operation fires next.
+	NextRuns ScheduledRuns `json:"nextRuns,omitempty"`
+
+	// PolicyConflicts lists the other policies whose selectors also match
+	// this database. Only the policy in EffectiveConfig applies.
+	PolicyConflicts []string `json:"policyConflicts,omitempty"`
+
+	// MissingRepositories lists the repositories the policy names that do
+	// not exist (or do not allow the namespace). They are left out of
+	// EffectiveConfig and receive no backups.
+	MissingRepositories []string `json:"missingRepositories,omitempty"`
+}
+
+// RepositoryBackupStatus is the backup history for one repository.
+type RepositoryBackupStatus struct {
+	Repository string      `json:"repository"`
+	LastBackup metav1.Time `json:"lastBackup,omitempty"`
+	// Result is "succeeded" or "failed".
+	Result    string `json:"result,omitempty"`
+	Duration  string `json:"duration,omitempty"`
+	LastError string `json:"lastError,omitempty"`
+	// Snapshots lists the most recent snapshots written by the operator,
+	// newest first, capped at MaxRecordedSnapshots.
+	Snapshots []SnapshotStatus `json:"snapshots,omitempty"`
+}
+
+// SnapshotStatus describes one restic snapshot.
+type SnapshotStatus struct {
+	ID        string      `json:"id"`
+	Time      metav1.Time `json:"time"`
+	SizeBytes int64       `json:"sizeBytes,omitempty"`
+}
+
+// TriageStatus summarises the last triage.
+type TriageStatus struct {
+	Time metav1.Time `json:"time"`
+	// Result is "healthy", "unhealthy" or "split-brain".
+	Result            string   `json:"result"`
+	ReadyCount        int      `json:"readyCount"`
+	TotalCount        int      `json:"totalCount"`
+	ClusterPhase      string   `json:"clusterPhase,omitempty"`
+	AuthorityStatus   string   `json:"authorityStatus,omitempty"`
+	RecommendedDonor  string   `json:"recommendedDonor,omitempty"`
+	SplitBrainDetails []string `json:"splitBrainDetails,omitempty"`
+
+	// RPOViolations lists repositories whose last successful backup was
+	// older than the RPO when the triage ran.
+	RPOViolations []string `json:"rpoViolations,omitempty"`
+}
+
+// TriageSnapshot is one entry of the triage history.
+type TriageSnapshot struct {
+	Time metav1.Time `json:"time"`
+	// Result is "healthy", "unhealthy" or "split-brain".
+	Result       string             `json:"result"`
+	ReadyCount   int                `json:"readyCount"`
+	TotalCount   int                `json:"totalCount"`
+	ClusterPhase string             `json:"clusterPhase,omitempty"`
+	Instances    []InstanceSnapshot `json:"instances,omitempty"`
+}
+
+// InstanceSnapshot is the per-instance part of a TriageSnapshot.
+type InstanceSnapshot struct {
+	Pod   string `json:"pod"`
+	Ready bool   `json:"ready"`
+	// Primary is the CNPG primary, or a Galera node in the primary component.
+	Primary bool `json:"primary,omitempty"`
+	// Position is the CNPG checkpoint LSN or the Galera seqno.
+	Position string `json:"position,omitempty"`
+	// Lag is how far the instance trails the most advanced one: bytes of
+	// WAL for CNPG, seqno for Galera.
+	Lag     int64 `json:"lag"`
+	DiskPct int   `json:"diskPct"`
+}
+
+// RepairStatus summarises the last auto-repair.
+type RepairStatus struct {
+	Time metav1.Time `json:"time"`
+	// Result is "succeeded", "failed" or "refused" (safety gate).
+	Result          string   `json:"result"`
+	HealedInstances []string `json:"healedInstances,omitempty"`
+	Duration        string   `json:"duration,omitempty"`
+	Error           string   `json:"error,omitempty"`
+}
+
+// RepairGuardStatus tracks recent auto-repairs for RepairLimits.
+type RepairGuardStatus struct {
+	// RecentRepairs are the start times of auto-repairs within the window.
+	RecentRepairs []metav1.Time `json:"recentRepairs,omitempty"`
+
+	// ConsecutiveFailures counts failed auto-repairs since the last success.
+	ConsecutiveFailures int `json:"consecutiveFailures,omitempty"`
+
+	// BackoffUntil is when auto-repair may run again after a failure.
+	BackoffUntil *metav1.Time `json:"backoffUntil,omitempty"`
+
+	// CircuitOpen stops auto-repair until reset with the
+	// reset-repair-circuit annotation.
+	CircuitOpen     bool         `json:"circuitOpen,omitempty"`
+	CircuitOpenedAt *metav1.Time `json:"circuitOpenedAt,omitempty"`
+
+	// LastError is the error of the last failed auto-repair.
+	LastError string `json:"lastError,omitempty"`
+}
+
+// PruneStatus summarises the last retention run.
+type PruneStatus struct {
+	Time       metav1.Time `json:"time"`
+	Repository string      `json:"repository"`
+	// Result is "succeeded" or "failed".
+	Result  string `json:"result"`
+	Kept    int    `json:"kept,omitempty"`
+	Removed int    `json:"removed,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ScheduledRuns holds the next fire time of each scheduled operation.
+type ScheduledRuns struct {
+	Backup *metav1.Time `json:"backup,omitempty"`
+	Triage *metav1.Time `json:"triage,omitempty"`
+	Prune  *metav1.Time `json:"prune,omitempty"`
+}
+
+// ManagedDatabaseList contains a list of ManagedDatabase resources.
+type ManagedDatabaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ManagedDatabase `json:"items"`
+}
+
+// ManagedDatabaseName returns the ManagedDatabase name for a database CR.
+// The engine prefix keeps a CNPG Cluster and a MariaDB of the same name apart.
+func ManagedDatabaseName(engine, clusterName string) string {
+	return engine + "-" + clusterName
+}
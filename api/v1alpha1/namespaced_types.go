@@ -0,0 +1,40 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// NamespacedBackupPolicy is a BackupPolicy owned by a tenant namespace.
+// A database's policy annotation resolves to a NamespacedBackupPolicy of that
+// name in the database's namespace first, then to the cluster-scoped
+// BackupPolicy. Its repositories resolve the same way, and cluster-scoped
+// repositories must list the namespace in spec.allowedNamespaces.
+type NamespacedBackupPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BackupPolicySpec `json:"spec,omitempty"`
+}
+
+// NamespacedBackupPolicyList contains a list of NamespacedBackupPolicy resources.
+type NamespacedBackupPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NamespacedBackupPolicy `json:"items"`
+}
+
+// NamespacedBackupRepository is a restic repository owned by a tenant
+// namespace. It is only visible to databases in that namespace, and its
+// Secret references always resolve in that namespace.
+type NamespacedBackupRepository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackupRepositorySpec   `json:"spec,omitempty"`
+	Status BackupRepositoryStatus `json:"status,omitempty"`
+}
+
+// NamespacedBackupRepositoryList contains a list of NamespacedBackupRepository resources.
+type NamespacedBackupRepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NamespacedBackupRepository `json:"items"`
+}
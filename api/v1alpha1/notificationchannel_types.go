@@ -0,0 +1,67 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// Notification channel types.
+const (
+	NotificationTypeWebhook      = "webhook"
+	NotificationTypeSlack        = "slack"
+	NotificationTypeAlertmanager = "alertmanager"
+)
+
+// NotificationChannel is an endpoint that receives operator notifications.
+// Cluster-scoped; policies reference channels by name.
+type NotificationChannel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NotificationChannelSpec `json:"spec,omitempty"`
+}
+
+// NotificationChannelSpec defines where and which notifications are sent.
+type NotificationChannelSpec struct {
+	// Type is "webhook" (generic JSON POST), "slack" (Slack or Mattermost
+	// incoming webhook) or "alertmanager" (Alertmanager v2 API).
+	Type string `json:"type"`
+
+	// URL is the webhook URL, or the Alertmanager base URL
+	// (e.g. "http://alertmanager.monitoring.svc:9093").
+	URL string `json:"url,omitempty"`
+
+	// URLSecretRef reads the URL from a Secret instead, for webhook URLs
+	// that embed a token. Takes precedence over URL.
+	URLSecretRef *SecretKeyRef `json:"urlSecretRef,omitempty"`
+
+	// HeadersSecretRef references a Secret whose keys are sent as HTTP
+	// headers, e.g. Authorization.
+	HeadersSecretRef *SecretRef `json:"headersSecretRef,omitempty"`
+
+	// Reasons limits the channel to these event reasons (e.g.
+	// "BackupFailed", "RepairSucceeded"). Empty sends every Warning event.
+	Reasons []string `json:"reasons,omitempty"`
+
+	// Channel and Username override the incoming webhook's defaults
+	// (slack only).
+	Channel  string `json:"channel,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// Accepts reports whether the channel wants an event with the given reason.
+func (s *NotificationChannelSpec) Accepts(reason string, warning bool) bool {
+	if len(s.Reasons) == 0 {
+		return warning
+	}
+	for _, r := range s.Reasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationChannelList contains a list of NotificationChannel resources.
+type NotificationChannelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NotificationChannel `json:"items"`
+}
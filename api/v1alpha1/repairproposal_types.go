@@ -0,0 +1,119 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// RepairProposal phases.
+const (
+	RepairProposalPending   = "Pending"
+	RepairProposalApproved  = "Approved"
+	RepairProposalRunning   = "Running"
+	RepairProposalSucceeded = "Succeeded"
+	RepairProposalFailed    = "Failed"
+	// RepairProposalExpired means a later triage found the database healthy
+	// before the proposal was approved.
+	RepairProposalExpired = "Expired"
+)
+
+// Repair proposal triggers.
+const (
+	RepairTriggerSplitBrain = "split-brain"
+	RepairTriggerSafetyGate = "safety-gate"
+)
+
+// AnnotationDonorOverride names the donor ordinal an approver wants used
+// instead of the proposed one (RepairProposal only).
+const AnnotationDonorOverride = "clinic.hasteward.prplanit.com/donor-override"
+
+// AnnotationApprovedDonor pins the donor-override value that was in place
+// when a RepairProposal was approved. The admission webhook stamps it with
+// the approval and keeps clients from changing it; a proposal whose override
+// differs from it is not run.
+const AnnotationApprovedDonor = "clinic.hasteward.prplanit.com/approved-donor"
+
+// LabelDatabase is set on RepairProposals to the ManagedDatabase name of the
+// database they concern.
+const LabelDatabase = "clinic.hasteward.prplanit.com/database"
+
+// RepairProposal is a repair the operator would not run on its own: triage
+// found split-brain, or a safety gate refused auto-repair. Namespaced,
+// created by the operator in mode=repair and owned by the ManagedDatabase.
+// Approving it (AnnotationApprovedBy, optionally AnnotationDonorOverride)
+// runs a forced, targeted repair of each proposed instance.
+type RepairProposal struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RepairProposalSpec   `json:"spec,omitempty"`
+	Status RepairProposalStatus `json:"status,omitempty"`
+}
+
+// RepairProposalSpec is the plan, written once by the operator.
+type RepairProposalSpec struct {
+	// Engine is "cnpg" or "galera".
+	Engine string `json:"engine"`
+
+	// ClusterName is the name of the CNPG Cluster or MariaDB CR.
+	ClusterName string `json:"clusterName"`
+
+	// Trigger is "split-brain" or "safety-gate".
+	Trigger string `json:"trigger"`
+
+	// Reasons are the safety-gate refusal and split-brain details.
+	Reasons []string `json:"reasons,omitempty"`
+
+	// Targets are the instances the repair would heal (wipe and re-sync).
+	Targets []ProposedHealTarget `json:"targets,omitempty"`
+
+	// Donor is the proposed authoritative instance, if one is known.
+	Donor *ProposedDonor `json:"donor,omitempty"`
+
+	// Triage summarises the triage the proposal was made from.
+	Triage TriageStatus `json:"triage"`
+}
+
+// ProposedHealTarget is one instance in the repair plan.
+type ProposedHealTarget struct {
+	Pod      string `json:"pod"`
+	Instance int    `json:"instance"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// ProposedDonor is the donor the repair would sync from.
+type ProposedDonor struct {
+	Pod     string `json:"pod"`
+	Ordinal int    `json:"ordinal"`
+	// Mode is "auto" or "explicit" when resolved by the Galera safety gate,
+	// or "recommended" when taken from triage.
+	Mode         string   `json:"mode"`
+	StateComment string   `json:"stateComment,omitempty"`
+	Warnings     []string `json:"warnings,omitempty"`
+}
+
+// RepairProposalStatus is the approval state and outcome.
+type RepairProposalStatus struct {
+	// Phase is Pending, Approved, Running, Succeeded, Failed or Expired.
+	Phase string `json:"phase,omitempty"`
+
+	// Message explains why the proposal is waiting or expired.
+	Message string `json:"message,omitempty"`
+
+	ApprovedBy string `json:"approvedBy,omitempty"`
+
+	// DonorInstance is the donor ordinal the approved repair uses.
+	DonorInstance *int `json:"donorInstance,omitempty"`
+
+	StartTime      *metav1.Time `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	HealedInstances []string `json:"healedInstances,omitempty"`
+
+	// Error explains a Failed phase.
+	Error string `json:"error,omitempty"`
+}
+
+// RepairProposalList contains a list of RepairProposal resources.
+type RepairProposalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RepairProposal `json:"items"`
+}
@@ -0,0 +1,78 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// RestoreRequest phases. Fencing, Streaming and Unfencing follow the restore
+// lifecycle in the engine.
+const (
+	RestorePendingApproval = "PendingApproval"
+	RestorePending         = "Pending"
+	RestoreFencing         = "Fencing"
+	RestoreStreaming       = "Streaming"
+	RestoreUnfencing       = "Unfencing"
+	RestoreSucceeded       = "Succeeded"
+	RestoreFailed          = "Failed"
+)
+
+// RestoreRequest asks the operator to restore a managed database from a
+// restic snapshot. Namespaced, in the database's namespace. Nothing runs until
+// the request is approved with the AnnotationApprovedBy annotation, set in a
+// separate update from the one that created it. The spec is immutable.
+type RestoreRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RestoreRequestSpec   `json:"spec,omitempty"`
+	Status RestoreRequestStatus `json:"status,omitempty"`
+}
+
+// RestoreRequestSpec identifies the database and the snapshot to restore.
+type RestoreRequestSpec struct {
+	// Engine is "cnpg" or "galera".
+	Engine string `json:"engine"`
+
+	// ClusterName is the name of the CNPG Cluster or MariaDB CR.
+	ClusterName string `json:"clusterName"`
+
+	// Repository is the BackupRepository to restore from. It must be one of
+	// the database's effective repositories.
+	Repository string `json:"repository"`
+
+	// Snapshot is a restic snapshot ID or "latest" (default).
+	Snapshot string `json:"snapshot,omitempty"`
+
+	// Instance selects the per-instance dump in a diverged snapshot
+	// (written by repair escrow). Unset restores a regular backup.
+	Instance *int `json:"instance,omitempty"`
+}
+
+// RestoreRequestStatus is the progress and outcome written by the operator.
+type RestoreRequestStatus struct {
+	// Phase is PendingApproval, Pending, Fencing, Streaming, Unfencing,
+	// Succeeded or Failed.
+	Phase string `json:"phase,omitempty"`
+
+	// Message explains why the request is waiting.
+	Message string `json:"message,omitempty"`
+
+	// ApprovedBy is the value of the approval annotation when the restore started.
+	ApprovedBy string `json:"approvedBy,omitempty"`
+
+	StartTime      *metav1.Time `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	Duration       string       `json:"duration,omitempty"`
+
+	// SnapshotID is the snapshot that was restored ("latest" if requested so).
+	SnapshotID    string `json:"snapshotID,omitempty"`
+	BytesRestored int64  `json:"bytesRestored,omitempty"`
+
+	// Error explains a Failed phase.
+	Error string `json:"error,omitempty"`
+}
+
+// RestoreRequestList contains a list of RestoreRequest resources.
+type RestoreRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RestoreRequest `json:"items"`
+}
@@ -0,0 +1,72 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+)
+
+// RPODuration returns the parsed recovery point objective, or 0 if none is
+// set or it does not parse.
+func (c *EffectiveConfig) RPODuration() time.Duration {
+	if c.RPO == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(c.RPO)
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+// BackupAge describes how stale a database's backups to one repository are.
+type BackupAge struct {
+	Repository string
+	// LastSuccess is zero if the operator has no successful backup on record.
+	LastSuccess time.Time
+	// Age is the time since LastSuccess, or since the ManagedDatabase was
+	// created when there is none.
+	Age      time.Duration
+	Violated bool
+}
+
+// BackupAges evaluates each of the database's effective repositories against
+// the RPO at now. A repository with no recorded success counts from the
+// ManagedDatabase's creation, so a newly managed database is not in
+// violation before its first RPO has elapsed.
+func (md *ManagedDatabase) BackupAges(now time.Time) []BackupAge {
+	rpo := md.Status.EffectiveConfig.RPODuration()
+	var ages []BackupAge
+	for _, repo := range md.Status.EffectiveConfig.Repositories {
+		a := BackupAge{Repository: repo}
+		for _, b := range md.Status.Backups {
+			if b.Repository == repo && len(b.Snapshots) > 0 {
+				a.LastSuccess = b.Snapshots[0].Time.Time
+			}
+		}
+		since := a.LastSuccess
+		if since.IsZero() {
+			since = md.CreationTimestamp.Time
+		}
+		a.Age = now.Sub(since)
+		a.Violated = rpo > 0 && a.Age > rpo
+		ages = append(ages, a)
+	}
+	return ages
+}
+
+// RPOViolations describes each repository in violation of the RPO at now.
+func (md *ManagedDatabase) RPOViolations(now time.Time) []string {
+	var out []string
+	for _, a := range md.BackupAges(now) {
+		if !a.Violated {
+			continue
+		}
+		if a.LastSuccess.IsZero() {
+			out = append(out, fmt.Sprintf("%s: no successful backup (RPO %s)", a.Repository, md.Status.EffectiveConfig.RPO))
+			continue
+		}
+		out = append(out, fmt.Sprintf("%s: last backup %s ago (RPO %s)",
+			a.Repository, a.Age.Truncate(time.Minute), md.Status.EffectiveConfig.RPO))
+	}
+	return out
+}
@@ -0,0 +1,289 @@
+package v1alpha1
+
+import (
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Modes accepted in BackupPolicySpec.Mode and the mode annotation. Empty
+// behaves like "triage".
+var validModes = []string{"triage", "repair", "disabled"}
+
+// scheduleParser matches the operator's cron.WithSeconds() scheduler: six
+// fields, seconds first, or a descriptor such as "@daily".
+var scheduleParser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// readDataSubset matches the forms restic check --read-data-subset accepts:
+// "n/t", a percentage, or a size with an optional K/M/G/T suffix.
+var readDataSubset = regexp.MustCompile(`^(\d+/\d+|\d+(\.\d+)?%|\d+[KMGT]?)$`)
+
+// ValidateBackupPolicySpec checks a BackupPolicy or NamespacedBackupPolicy spec.
+func ValidateBackupPolicySpec(spec *BackupPolicySpec, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateSchedule(spec.BackupSchedule, path.Child("backupSchedule"))...)
+	errs = append(errs, validateSchedule(spec.TriageSchedule, path.Child("triageSchedule"))...)
+	errs = append(errs, validateSchedule(spec.PruneSchedule, path.Child("pruneSchedule"))...)
+	errs = append(errs, validateMode(spec.Mode, path.Child("mode"))...)
+	errs = append(errs, validateRPO(spec.RPO, path.Child("rpo"))...)
+
+	if spec.StartingDeadlineSeconds != nil && *spec.StartingDeadlineSeconds < 0 {
+		errs = append(errs, field.Invalid(path.Child("startingDeadlineSeconds"), *spec.StartingDeadlineSeconds, "must be >= 0"))
+	}
+	errs = append(errs, validateNonNegative(spec.Retention.KeepLast, path.Child("retention", "keepLast"))...)
+	errs = append(errs, validateNonNegative(spec.Retention.KeepDaily, path.Child("retention", "keepDaily"))...)
+	errs = append(errs, validateNonNegative(spec.Retention.KeepWeekly, path.Child("retention", "keepWeekly"))...)
+	errs = append(errs, validateNonNegative(spec.Retention.KeepMonthly, path.Child("retention", "keepMonthly"))...)
+	errs = append(errs, validateNonNegative(spec.HealTimeout, path.Child("healTimeout"))...)
+	errs = append(errs, validateNonNegative(spec.DeleteTimeout, path.Child("deleteTimeout"))...)
+	errs = append(errs, validateNameList(spec.Repositories, path.Child("repositories"))...)
+	errs = append(errs, validateNameList(spec.NotificationChannels, path.Child("notificationChannels"))...)
+	errs = append(errs, validateWindows(spec.MaintenanceWindows, path.Child("maintenanceWindows"))...)
+	errs = append(errs, validateWindows(spec.BlackoutWindows, path.Child("blackoutWindows"))...)
+	if l := spec.RepairLimits; l != nil {
+		p := path.Child("repairLimits")
+		errs = append(errs, validateNonNegative(l.MaxRepairs, p.Child("maxRepairs"))...)
+		errs = append(errs, validateNonNegative(l.FailureThreshold, p.Child("failureThreshold"))...)
+		errs = append(errs, validateDuration(l.Window, p.Child("window"))...)
+		errs = append(errs, validateDuration(l.Backoff, p.Child("backoff"))...)
+		errs = append(errs, validateDuration(l.MaxBackoff, p.Child("maxBackoff"))...)
+	}
+
+	if spec.Selector != nil {
+		opts := metav1validation.LabelSelectorValidationOptions{}
+		errs = append(errs, metav1validation.ValidateLabelSelector(spec.Selector.NamespaceSelector, opts, path.Child("selector", "namespaceSelector"))...)
+		errs = append(errs, metav1validation.ValidateLabelSelector(spec.Selector.ObjectSelector, opts, path.Child("selector", "objectSelector"))...)
+	}
+	return errs
+}
+
+// ValidateBackupRepositorySpec checks a repository spec. namespace is the
+// NamespacedBackupRepository's namespace, or empty for a BackupRepository.
+// Warnings flag settings that are accepted but have no effect.
+func ValidateBackupRepositorySpec(spec *BackupRepositorySpec, namespace string, path *field.Path) (field.ErrorList, []string) {
+	var errs field.ErrorList
+	var warnings []string
+
+	restic := path.Child("restic")
+	if spec.Restic.Repository == "" {
+		errs = append(errs, field.Required(restic.Child("repository"), ""))
+	}
+	pw := restic.Child("passwordSecretRef")
+	if spec.Restic.PasswordSecretRef.Name == "" {
+		errs = append(errs, field.Required(pw.Child("name"), ""))
+	}
+	if spec.Restic.PasswordSecretRef.Key == "" {
+		errs = append(errs, field.Required(pw.Child("key"), ""))
+	}
+	errs = append(errs, validateSecretNamespace(spec.Restic.PasswordSecretRef.Namespace, namespace, pw.Child("namespace"))...)
+	if ref := spec.Restic.EnvSecretRef; ref != nil {
+		env := restic.Child("envSecretRef")
+		if ref.Name == "" {
+			errs = append(errs, field.Required(env.Child("name"), ""))
+		}
+		errs = append(errs, validateSecretNamespace(ref.Namespace, namespace, env.Child("namespace"))...)
+	}
+
+	errs = append(errs, validateSchedule(spec.CheckSchedule, path.Child("checkSchedule"))...)
+	if v := spec.CheckReadDataSubset; v != "" && !readDataSubset.MatchString(v) {
+		errs = append(errs, field.Invalid(path.Child("checkReadDataSubset"), v, `must be "n/t", a percentage such as "5%" or a size such as "500M"`))
+	}
+	errs = append(errs, validateNonNegative(spec.MaxConcurrency, path.Child("maxConcurrency"))...)
+
+	for i, ns := range spec.AllowedNamespaces {
+		if ns == "" {
+			errs = append(errs, field.Invalid(path.Child("allowedNamespaces").Index(i), ns, "must not be empty"))
+		}
+	}
+	if namespace != "" && len(spec.AllowedNamespaces) > 0 {
+		warnings = append(warnings, "spec.allowedNamespaces has no effect on a NamespacedBackupRepository")
+	}
+	return errs, warnings
+}
+
+// ValidateNotificationChannelSpec checks a NotificationChannel spec.
+func ValidateNotificationChannelSpec(spec *NotificationChannelSpec, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	types := []string{NotificationTypeWebhook, NotificationTypeSlack, NotificationTypeAlertmanager}
+	if !slices.Contains(types, spec.Type) {
+		errs = append(errs, field.NotSupported(path.Child("type"), spec.Type, types))
+	}
+
+	switch {
+	case spec.URLSecretRef != nil:
+		ref := path.Child("urlSecretRef")
+		if spec.URLSecretRef.Name == "" {
+			errs = append(errs, field.Required(ref.Child("name"), ""))
+		}
+		if spec.URLSecretRef.Namespace == "" {
+			errs = append(errs, field.Required(ref.Child("namespace"), ""))
+		}
+		if spec.URLSecretRef.Key == "" {
+			errs = append(errs, field.Required(ref.Child("key"), ""))
+		}
+	case spec.URL == "":
+		errs = append(errs, field.Required(path.Child("url"), "url or urlSecretRef is required"))
+	default:
+		if u, err := url.Parse(spec.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, field.Invalid(path.Child("url"), spec.URL, "must be an absolute http or https URL"))
+		}
+	}
+
+	if ref := spec.HeadersSecretRef; ref != nil {
+		if ref.Name == "" {
+			errs = append(errs, field.Required(path.Child("headersSecretRef", "name"), ""))
+		}
+		if ref.Namespace == "" {
+			errs = append(errs, field.Required(path.Child("headersSecretRef", "namespace"), ""))
+		}
+	}
+	errs = append(errs, validateNameList(spec.Reasons, path.Child("reasons"))...)
+	return errs
+}
+
+// ValidateAnnotations checks the hasteward annotations on a CNPG Cluster or
+// MariaDB CR against the same rules as the BackupPolicy fields they
+// override. Unknown clinic.hasteward.prplanit.com/ keys are returned as
+// warnings rather than errors.
+func ValidateAnnotations(annotations map[string]string) (field.ErrorList, []string) {
+	var errs field.ErrorList
+	var warnings []string
+	path := field.NewPath("metadata", "annotations")
+
+	for _, key := range slices.Sorted(maps.Keys(annotations)) {
+		if !strings.HasPrefix(key, AnnotationPrefix) {
+			continue
+		}
+		v := annotations[key]
+		p := path.Key(key)
+		switch key {
+		case AnnotationPolicy, AnnotationManaged:
+		case AnnotationBackupSchedule, AnnotationTriageSchedule, AnnotationPruneSchedule:
+			errs = append(errs, validateSchedule(v, p)...)
+		case AnnotationMode:
+			errs = append(errs, validateMode(v, p)...)
+		case AnnotationRPO:
+			errs = append(errs, validateRPO(v, p)...)
+		case AnnotationRepositories, AnnotationNotificationChannels:
+			errs = append(errs, validateNameList(splitCSV(v), p)...)
+		case AnnotationStartingDeadline, AnnotationRetentionKeepLast, AnnotationRetentionKeepDaily,
+			AnnotationRetentionKeepWeekly, AnnotationRetentionKeepMonthly,
+			AnnotationHealTimeout, AnnotationDeleteTimeout:
+			if n, err := strconv.ParseInt(v, 10, 64); err != nil || n < 0 {
+				errs = append(errs, field.Invalid(p, v, "must be a non-negative integer"))
+			}
+		case AnnotationMaintenanceWindows, AnnotationBlackoutWindows:
+			if _, err := ParseWindows(v); err != nil {
+				errs = append(errs, field.Invalid(p, v, err.Error()))
+			}
+		case AnnotationExclude:
+			if v != "true" && v != "false" {
+				errs = append(errs, field.NotSupported(p, v, []string{"true", "false"}))
+			}
+		case AnnotationLastBackup, AnnotationLastBackupDuration, AnnotationLastTriage,
+			AnnotationLastTriageResult, AnnotationLastRepair, AnnotationLastPrune, AnnotationLastPruneResult:
+			// Written by earlier operator versions
+		default:
+			warnings = append(warnings, "unknown annotation "+key+" is ignored by hasteward")
+		}
+	}
+	return errs, warnings
+}
+
+func validateSchedule(spec string, p *field.Path) field.ErrorList {
+	if spec == "" {
+		return nil
+	}
+	if _, err := scheduleParser.Parse(spec); err != nil {
+		return field.ErrorList{field.Invalid(p, spec, "must be a 6-field cron expression with seconds (e.g. \"0 0 2 * * *\") or a descriptor: "+err.Error())}
+	}
+	return nil
+}
+
+func validateWindows(windows []TimeWindow, p *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, w := range windows {
+		if _, _, err := w.parse(); err != nil {
+			errs = append(errs, field.Invalid(p.Index(i), w, err.Error()))
+		}
+	}
+	return errs
+}
+
+func validateMode(mode string, p *field.Path) field.ErrorList {
+	if mode == "" {
+		return nil
+	}
+	for _, m := range validModes {
+		if mode == m {
+			return nil
+		}
+	}
+	return field.ErrorList{field.NotSupported(p, mode, validModes)}
+}
+
+func validateRPO(rpo string, p *field.Path) field.ErrorList {
+	if rpo == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(rpo)
+	if err != nil || d <= 0 {
+		return field.ErrorList{field.Invalid(p, rpo, "must be a positive Go duration such as \"26h\"")}
+	}
+	return nil
+}
+
+func validateDuration(s string, p *field.Path) field.ErrorList {
+	if s == "" {
+		return nil
+	}
+	if d, err := time.ParseDuration(s); err != nil || d <= 0 {
+		return field.ErrorList{field.Invalid(p, s, "must be a positive Go duration such as \"15m\"")}
+	}
+	return nil
+}
+
+func validateNonNegative(n int, p *field.Path) field.ErrorList {
+	if n < 0 {
+		return field.ErrorList{field.Invalid(p, n, "must be >= 0")}
+	}
+	return nil
+}
+
+func validateNameList(names []string, p *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	seen := make(map[string]bool, len(names))
+	for i, name := range names {
+		switch {
+		case name == "":
+			errs = append(errs, field.Invalid(p.Index(i), name, "must not be empty"))
+		case seen[name]:
+			errs = append(errs, field.Duplicate(p.Index(i), name))
+		}
+		seen[name] = true
+	}
+	return errs
+}
+
+// validateSecretNamespace requires a namespace on cluster-scoped repositories
+// and confines namespaced ones to their own namespace.
+func validateSecretNamespace(refNamespace, repoNamespace string, p *field.Path) field.ErrorList {
+	if repoNamespace == "" {
+		if refNamespace == "" {
+			return field.ErrorList{field.Required(p, "")}
+		}
+		return nil
+	}
+	if refNamespace != "" && refNamespace != repoNamespace {
+		return field.ErrorList{field.Invalid(p, refNamespace, "must be empty or the repository's own namespace")}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ParseWindows parses the annotation form of a window list:
+// "schedule|duration" entries separated by semicolons, e.g.
+// "0 0 2 * * *|4h;0 0 14 * * 6|2h". An empty string is an empty list.
+func ParseWindows(s string) ([]TimeWindow, error) {
+	var windows []TimeWindow
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		schedule, duration, ok := strings.Cut(entry, "|")
+		if !ok {
+			return nil, fmt.Errorf("window %q: expected schedule|duration", entry)
+		}
+		w := TimeWindow{Schedule: strings.TrimSpace(schedule), Duration: strings.TrimSpace(duration)}
+		if _, _, err := w.parse(); err != nil {
+			return nil, err
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+// intervalSamples is how many consecutive starts minInterval compares.
+const intervalSamples = 64
+
+// parse returns the window's start schedule and length. A window may not
+// outlast the interval between its starts, so at most one start is ever open.
+func (w TimeWindow) parse() (cron.Schedule, time.Duration, error) {
+	sched, err := scheduleParser.Parse(w.Schedule)
+	if err != nil {
+		return nil, 0, fmt.Errorf("window schedule %q: %w", w.Schedule, err)
+	}
+	d, err := time.ParseDuration(w.Duration)
+	if err != nil || d <= 0 {
+		return nil, 0, fmt.Errorf("window duration %q: must be a positive Go duration", w.Duration)
+	}
+	if interval := minInterval(sched, time.Now()); interval > 0 && d > interval {
+		return nil, 0, fmt.Errorf("window duration %q: longer than the %s between starts of %q", w.Duration, interval, w.Schedule)
+	}
+	return sched, d, nil
+}
+
+// minInterval returns the shortest gap between the next intervalSamples
+// starts of sched after from, or 0 if it fires fewer than twice.
+func minInterval(sched cron.Schedule, from time.Time) time.Duration {
+	var shortest time.Duration
+	prev := sched.Next(from)
+	for i := 0; i < intervalSamples && !prev.IsZero(); i++ {
+		next := sched.Next(prev)
+		if next.IsZero() {
+			break
+		}
+		if gap := next.Sub(prev); shortest == 0 || gap < shortest {
+			shortest = gap
+		}
+		prev = next
+	}
+	return shortest
+}
+
+// ActiveWindow reports whether now falls inside any of the windows and, if
+// so, when the latest-ending of them closes. Invalid windows are ignored.
+func ActiveWindow(windows []TimeWindow, now time.Time) (end time.Time, active bool) {
+	for _, w := range windows {
+		sched, d, err := w.parse()
+		if err != nil {
+			continue
+		}
+		// d never exceeds the interval between starts, so the only start
+		// that can hold the window open is the first one in (now-d, now]
+		t := sched.Next(now.Add(-d))
+		if t.IsZero() || t.After(now) {
+			continue
+		}
+		if e := t.Add(d); e.After(end) {
+			end = e
+			active = true
+		}
+	}
+	return end, active
+}
+
+// NextWindowStart returns the earliest time after now at which any of the
+// windows opens, or the zero time if none will.
+func NextWindowStart(windows []TimeWindow, now time.Time) time.Time {
+	var next time.Time
+	for _, w := range windows {
+		sched, _, err := w.parse()
+		if err != nil {
+			continue
+		}
+		if t := sched.Next(now); !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	return next
+}
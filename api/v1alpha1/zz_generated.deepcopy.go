@@ -1,6 +1,9 @@
 package v1alpha1
 
-import "k8s.io/apimachinery/pkg/runtime"
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
 
 // --- BackupRepository ---
 
@@ -30,6 +33,10 @@ func (in *BackupRepository) DeepCopyObject() runtime.Object {
 func (in *BackupRepositorySpec) DeepCopyInto(out *BackupRepositorySpec) {
 	*out = *in
 	in.Restic.DeepCopyInto(&out.Restic)
+	if in.AllowedNamespaces != nil {
+		out.AllowedNamespaces = make([]string, len(in.AllowedNamespaces))
+		copy(out.AllowedNamespaces, in.AllowedNamespaces)
+	}
 }
 
 // --- ResticSpec ---
@@ -47,6 +54,7 @@ func (in *ResticSpec) DeepCopyInto(out *ResticSpec) {
 
 func (in *BackupRepositoryStatus) DeepCopyInto(out *BackupRepositoryStatus) {
 	*out = *in
+	in.LastProbe.DeepCopyInto(&out.LastProbe)
 	in.LastCheck.DeepCopyInto(&out.LastCheck)
 }
 
@@ -104,10 +112,46 @@ func (in *BackupPolicy) DeepCopyObject() runtime.Object {
 func (in *BackupPolicySpec) DeepCopyInto(out *BackupPolicySpec) {
 	*out = *in
 	out.Retention = in.Retention
+	if in.StartingDeadlineSeconds != nil {
+		out.StartingDeadlineSeconds = new(int64)
+		*out.StartingDeadlineSeconds = *in.StartingDeadlineSeconds
+	}
 	if in.Repositories != nil {
 		out.Repositories = make([]string, len(in.Repositories))
 		copy(out.Repositories, in.Repositories)
 	}
+	if in.Selector != nil {
+		out.Selector = new(PolicySelector)
+		in.Selector.DeepCopyInto(out.Selector)
+	}
+	if in.MaintenanceWindows != nil {
+		out.MaintenanceWindows = make([]TimeWindow, len(in.MaintenanceWindows))
+		copy(out.MaintenanceWindows, in.MaintenanceWindows)
+	}
+	if in.BlackoutWindows != nil {
+		out.BlackoutWindows = make([]TimeWindow, len(in.BlackoutWindows))
+		copy(out.BlackoutWindows, in.BlackoutWindows)
+	}
+	if in.RepairLimits != nil {
+		out.RepairLimits = new(RepairLimits)
+		*out.RepairLimits = *in.RepairLimits
+	}
+	if in.NotificationChannels != nil {
+		out.NotificationChannels = make([]string, len(in.NotificationChannels))
+		copy(out.NotificationChannels, in.NotificationChannels)
+	}
+}
+
+// --- PolicySelector ---
+
+func (in *PolicySelector) DeepCopyInto(out *PolicySelector) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		out.NamespaceSelector = in.NamespaceSelector.DeepCopy()
+	}
+	if in.ObjectSelector != nil {
+		out.ObjectSelector = in.ObjectSelector.DeepCopy()
+	}
 }
 
 // --- BackupPolicyList ---
@@ -136,3 +180,619 @@ func (in *BackupPolicyList) DeepCopy() *BackupPolicyList {
 func (in *BackupPolicyList) DeepCopyObject() runtime.Object {
 	return in.DeepCopy()
 }
+
+// --- ManagedDatabase ---
+
+func (in *ManagedDatabase) DeepCopyInto(out *ManagedDatabase) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+func (in *ManagedDatabase) DeepCopy() *ManagedDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *ManagedDatabase) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// --- ManagedDatabaseStatus ---
+
+func (in *ManagedDatabaseStatus) DeepCopyInto(out *ManagedDatabaseStatus) {
+	*out = *in
+	in.EffectiveConfig.DeepCopyInto(&out.EffectiveConfig)
+	if in.Backups != nil {
+		out.Backups = make([]RepositoryBackupStatus, len(in.Backups))
+		for i := range in.Backups {
+			in.Backups[i].DeepCopyInto(&out.Backups[i])
+		}
+	}
+	if in.Triage != nil {
+		out.Triage = new(TriageStatus)
+		in.Triage.DeepCopyInto(out.Triage)
+	}
+	if in.TriageHistory != nil {
+		out.TriageHistory = make([]TriageSnapshot, len(in.TriageHistory))
+		for i := range in.TriageHistory {
+			in.TriageHistory[i].DeepCopyInto(&out.TriageHistory[i])
+		}
+	}
+	if in.Repair != nil {
+		out.Repair = new(RepairStatus)
+		in.Repair.DeepCopyInto(out.Repair)
+	}
+	if in.RepairGuard != nil {
+		out.RepairGuard = new(RepairGuardStatus)
+		in.RepairGuard.DeepCopyInto(out.RepairGuard)
+	}
+	if in.Prune != nil {
+		out.Prune = new(PruneStatus)
+		*out.Prune = *in.Prune
+		in.Prune.Time.DeepCopyInto(&out.Prune.Time)
+	}
+	in.NextRuns.DeepCopyInto(&out.NextRuns)
+	if in.PolicyConflicts != nil {
+		out.PolicyConflicts = make([]string, len(in.PolicyConflicts))
+		copy(out.PolicyConflicts, in.PolicyConflicts)
+	}
+	if in.MissingRepositories != nil {
+		out.MissingRepositories = make([]string, len(in.MissingRepositories))
+		copy(out.MissingRepositories, in.MissingRepositories)
+	}
+}
+
+// --- EffectiveConfig ---
+
+func (in *EffectiveConfig) DeepCopyInto(out *EffectiveConfig) {
+	*out = *in
+	if in.StartingDeadlineSeconds != nil {
+		out.StartingDeadlineSeconds = new(int64)
+		*out.StartingDeadlineSeconds = *in.StartingDeadlineSeconds
+	}
+	if in.Repositories != nil {
+		out.Repositories = make([]string, len(in.Repositories))
+		copy(out.Repositories, in.Repositories)
+	}
+	if in.MaintenanceWindows != nil {
+		out.MaintenanceWindows = make([]TimeWindow, len(in.MaintenanceWindows))
+		copy(out.MaintenanceWindows, in.MaintenanceWindows)
+	}
+	if in.BlackoutWindows != nil {
+		out.BlackoutWindows = make([]TimeWindow, len(in.BlackoutWindows))
+		copy(out.BlackoutWindows, in.BlackoutWindows)
+	}
+	if in.RepairLimits != nil {
+		out.RepairLimits = new(RepairLimits)
+		*out.RepairLimits = *in.RepairLimits
+	}
+	if in.NotificationChannels != nil {
+		out.NotificationChannels = make([]string, len(in.NotificationChannels))
+		copy(out.NotificationChannels, in.NotificationChannels)
+	}
+}
+
+// --- RepositoryBackupStatus ---
+
+func (in *RepositoryBackupStatus) DeepCopyInto(out *RepositoryBackupStatus) {
+	*out = *in
+	in.LastBackup.DeepCopyInto(&out.LastBackup)
+	if in.Snapshots != nil {
+		out.Snapshots = make([]SnapshotStatus, len(in.Snapshots))
+		for i := range in.Snapshots {
+			out.Snapshots[i] = in.Snapshots[i]
+			in.Snapshots[i].Time.DeepCopyInto(&out.Snapshots[i].Time)
+		}
+	}
+}
+
+// --- TriageStatus ---
+
+func (in *TriageStatus) DeepCopyInto(out *TriageStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.SplitBrainDetails != nil {
+		out.SplitBrainDetails = make([]string, len(in.SplitBrainDetails))
+		copy(out.SplitBrainDetails, in.SplitBrainDetails)
+	}
+	if in.RPOViolations != nil {
+		out.RPOViolations = make([]string, len(in.RPOViolations))
+		copy(out.RPOViolations, in.RPOViolations)
+	}
+}
+
+// --- TriageSnapshot ---
+
+func (in *TriageSnapshot) DeepCopyInto(out *TriageSnapshot) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Instances != nil {
+		out.Instances = make([]InstanceSnapshot, len(in.Instances))
+		copy(out.Instances, in.Instances)
+	}
+}
+
+// --- RepairStatus ---
+
+func (in *RepairStatus) DeepCopyInto(out *RepairStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.HealedInstances != nil {
+		out.HealedInstances = make([]string, len(in.HealedInstances))
+		copy(out.HealedInstances, in.HealedInstances)
+	}
+}
+
+// --- RepairGuardStatus ---
+
+func (in *RepairGuardStatus) DeepCopyInto(out *RepairGuardStatus) {
+	*out = *in
+	if in.RecentRepairs != nil {
+		out.RecentRepairs = make([]v1.Time, len(in.RecentRepairs))
+		for i := range in.RecentRepairs {
+			in.RecentRepairs[i].DeepCopyInto(&out.RecentRepairs[i])
+		}
+	}
+	if in.BackoffUntil != nil {
+		out.BackoffUntil = in.BackoffUntil.DeepCopy()
+	}
+	if in.CircuitOpenedAt != nil {
+		out.CircuitOpenedAt = in.CircuitOpenedAt.DeepCopy()
+	}
+}
+
+// --- ScheduledRuns ---
+
+func (in *ScheduledRuns) DeepCopyInto(out *ScheduledRuns) {
+	*out = *in
+	if in.Backup != nil {
+		out.Backup = in.Backup.DeepCopy()
+	}
+	if in.Triage != nil {
+		out.Triage = in.Triage.DeepCopy()
+	}
+	if in.Prune != nil {
+		out.Prune = in.Prune.DeepCopy()
+	}
+}
+
+// --- ManagedDatabaseList ---
+
+func (in *ManagedDatabaseList) DeepCopyInto(out *ManagedDatabaseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]ManagedDatabase, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+func (in *ManagedDatabaseList) DeepCopy() *ManagedDatabaseList {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedDatabaseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *ManagedDatabaseList) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// --- BackupRun ---
+
+func (in *BackupRun) DeepCopyInto(out *BackupRun) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+func (in *BackupRun) DeepCopy() *BackupRun {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *BackupRun) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// --- BackupRunSpec ---
+
+func (in *BackupRunSpec) DeepCopyInto(out *BackupRunSpec) {
+	*out = *in
+	if in.Repositories != nil {
+		out.Repositories = make([]string, len(in.Repositories))
+		copy(out.Repositories, in.Repositories)
+	}
+}
+
+// --- BackupRunStatus ---
+
+func (in *BackupRunStatus) DeepCopyInto(out *BackupRunStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		out.StartTime = in.StartTime.DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		out.CompletionTime = in.CompletionTime.DeepCopy()
+	}
+	if in.Results != nil {
+		out.Results = make([]BackupRunResult, len(in.Results))
+		copy(out.Results, in.Results)
+	}
+}
+
+// --- BackupRunList ---
+
+func (in *BackupRunList) DeepCopyInto(out *BackupRunList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]BackupRun, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+func (in *BackupRunList) DeepCopy() *BackupRunList {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRunList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *BackupRunList) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// --- RestoreRequest ---
+
+func (in *RestoreRequest) DeepCopyInto(out *RestoreRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+func (in *RestoreRequest) DeepCopy() *RestoreRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *RestoreRequest) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// --- RestoreRequestSpec ---
+
+func (in *RestoreRequestSpec) DeepCopyInto(out *RestoreRequestSpec) {
+	*out = *in
+	if in.Instance != nil {
+		out.Instance = new(int)
+		*out.Instance = *in.Instance
+	}
+}
+
+// --- RestoreRequestStatus ---
+
+func (in *RestoreRequestStatus) DeepCopyInto(out *RestoreRequestStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		out.StartTime = in.StartTime.DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		out.CompletionTime = in.CompletionTime.DeepCopy()
+	}
+}
+
+// --- RestoreRequestList ---
+
+func (in *RestoreRequestList) DeepCopyInto(out *RestoreRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]RestoreRequest, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+func (in *RestoreRequestList) DeepCopy() *RestoreRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *RestoreRequestList) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// --- RepairProposal ---
+
+func (in *RepairProposal) DeepCopyInto(out *RepairProposal) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+func (in *RepairProposal) DeepCopy() *RepairProposal {
+	if in == nil {
+		return nil
+	}
+	out := new(RepairProposal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *RepairProposal) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// --- RepairProposalSpec ---
+
+func (in *RepairProposalSpec) DeepCopyInto(out *RepairProposalSpec) {
+	*out = *in
+	if in.Reasons != nil {
+		out.Reasons = make([]string, len(in.Reasons))
+		copy(out.Reasons, in.Reasons)
+	}
+	if in.Targets != nil {
+		out.Targets = make([]ProposedHealTarget, len(in.Targets))
+		copy(out.Targets, in.Targets)
+	}
+	if in.Donor != nil {
+		out.Donor = new(ProposedDonor)
+		*out.Donor = *in.Donor
+		if in.Donor.Warnings != nil {
+			out.Donor.Warnings = make([]string, len(in.Donor.Warnings))
+			copy(out.Donor.Warnings, in.Donor.Warnings)
+		}
+	}
+	in.Triage.DeepCopyInto(&out.Triage)
+}
+
+// --- RepairProposalStatus ---
+
+func (in *RepairProposalStatus) DeepCopyInto(out *RepairProposalStatus) {
+	*out = *in
+	if in.DonorInstance != nil {
+		out.DonorInstance = new(int)
+		*out.DonorInstance = *in.DonorInstance
+	}
+	if in.StartTime != nil {
+		out.StartTime = in.StartTime.DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		out.CompletionTime = in.CompletionTime.DeepCopy()
+	}
+	if in.HealedInstances != nil {
+		out.HealedInstances = make([]string, len(in.HealedInstances))
+		copy(out.HealedInstances, in.HealedInstances)
+	}
+}
+
+// --- RepairProposalList ---
+
+func (in *RepairProposalList) DeepCopyInto(out *RepairProposalList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]RepairProposal, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+func (in *RepairProposalList) DeepCopy() *RepairProposalList {
+	if in == nil {
+		return nil
+	}
+	out := new(RepairProposalList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *RepairProposalList) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// --- NamespacedBackupPolicy ---
+
+func (in *NamespacedBackupPolicy) DeepCopyInto(out *NamespacedBackupPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+func (in *NamespacedBackupPolicy) DeepCopy() *NamespacedBackupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedBackupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *NamespacedBackupPolicy) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// --- NamespacedBackupPolicyList ---
+
+func (in *NamespacedBackupPolicyList) DeepCopyInto(out *NamespacedBackupPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]NamespacedBackupPolicy, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+func (in *NamespacedBackupPolicyList) DeepCopy() *NamespacedBackupPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedBackupPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *NamespacedBackupPolicyList) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// --- NamespacedBackupRepository ---
+
+func (in *NamespacedBackupRepository) DeepCopyInto(out *NamespacedBackupRepository) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+func (in *NamespacedBackupRepository) DeepCopy() *NamespacedBackupRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedBackupRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *NamespacedBackupRepository) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// --- NamespacedBackupRepositoryList ---
+
+func (in *NamespacedBackupRepositoryList) DeepCopyInto(out *NamespacedBackupRepositoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]NamespacedBackupRepository, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+func (in *NamespacedBackupRepositoryList) DeepCopy() *NamespacedBackupRepositoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedBackupRepositoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *NamespacedBackupRepositoryList) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// --- NotificationChannel ---
+
+func (in *NotificationChannel) DeepCopyInto(out *NotificationChannel) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+func (in *NotificationChannel) DeepCopy() *NotificationChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *NotificationChannel) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// --- NotificationChannelSpec ---
+
+func (in *NotificationChannelSpec) DeepCopyInto(out *NotificationChannelSpec) {
+	*out = *in
+	if in.URLSecretRef != nil {
+		out.URLSecretRef = new(SecretKeyRef)
+		*out.URLSecretRef = *in.URLSecretRef
+	}
+	if in.HeadersSecretRef != nil {
+		out.HeadersSecretRef = new(SecretRef)
+		*out.HeadersSecretRef = *in.HeadersSecretRef
+	}
+	if in.Reasons != nil {
+		out.Reasons = make([]string, len(in.Reasons))
+		copy(out.Reasons, in.Reasons)
+	}
+}
+
+// --- NotificationChannelList ---
+
+func (in *NotificationChannelList) DeepCopyInto(out *NotificationChannelList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]NotificationChannel, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+func (in *NotificationChannelList) DeepCopy() *NotificationChannelList {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationChannelList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *NotificationChannelList) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
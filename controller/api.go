@@ -0,0 +1,535 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+	"github.com/PrPlanIT/HASteward/src/common"
+	"github.com/PrPlanIT/HASteward/src/output"
+	"github.com/PrPlanIT/HASteward/src/output/model"
+	"github.com/PrPlanIT/HASteward/src/output/printer"
+	"github.com/PrPlanIT/HASteward/src/restic"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// APIPort is where the operator HTTP API listens.
+const APIPort = 8082
+
+// apiShutdownTimeout bounds how long in-flight API requests may take once the
+// manager stops.
+const apiShutdownTimeout = 5 * time.Second
+
+// apiServer serves the operator HTTP API: the scheduler's managed databases
+// and triggers for triage, backup and repair. Every request is authenticated
+// with a TokenReview of its bearer token and authorized with a
+// SubjectAccessReview against ManagedDatabases, so API access is granted with
+// ordinary RBAC.
+type apiServer struct {
+	sched   *Scheduler
+	client  client.Client
+	elected <-chan struct{}
+	certDir string // empty serves plain HTTP; Run only allows it with APIInsecure
+
+	// dashboard serves the embedded read-only web UI at /.
+	dashboard bool
+}
+
+// apiCaller is an authenticated API user.
+type apiCaller struct {
+	authenticationv1.UserInfo
+}
+
+// Start serves the API until ctx is cancelled. It implements manager.Runnable.
+func (a *apiServer) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/databases", a.listDatabases)
+	mux.HandleFunc("GET /api/v1/databases/{engine}/{namespace}/{name}", a.getDatabase)
+	mux.HandleFunc("GET /api/v1/databases/{engine}/{namespace}/{name}/triage", a.getTriage)
+	mux.HandleFunc("GET /api/v1/databases/{engine}/{namespace}/{name}/snapshots", a.listSnapshots)
+	mux.HandleFunc("POST /api/v1/databases/{engine}/{namespace}/{name}/{operation}", a.trigger)
+	if a.dashboard {
+		mux.HandleFunc("GET /{$}", serveDashboard)
+	}
+
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", APIPort),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		var err error
+		if a.certDir != "" {
+			common.InfoLog("Serving operator API on %s (TLS)", srv.Addr)
+			err = srv.ListenAndServeTLS(filepath.Join(a.certDir, "tls.crt"), filepath.Join(a.certDir, "tls.key"))
+		} else {
+			common.WarnLog("Serving operator API on %s over plain HTTP (--api-insecure): bearer tokens are sent in cleartext", srv.Addr)
+			err = srv.ListenAndServe()
+		}
+		errCh <- err
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("operator API: %w", err)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), apiShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection reports that the API runs on every replica. Standbys
+// have no registered databases and answer 503, so clients retry against the
+// leader.
+func (a *apiServer) NeedLeaderElection() bool {
+	return false
+}
+
+// listDatabases handles GET /api/v1/databases. The optional namespace and
+// engine query parameters narrow the list; without namespace the caller needs
+// list on manageddatabases in all namespaces.
+func (a *apiServer) listDatabases(w http.ResponseWriter, r *http.Request) {
+	const command = "api-get-databases"
+	start := time.Now()
+	namespace := r.URL.Query().Get("namespace")
+	engine := r.URL.Query().Get("engine")
+
+	if !a.admit(w, r, command, &authorizationv1.ResourceAttributes{Verb: "list", Namespace: namespace}) {
+		return
+	}
+
+	var entries []model.DatabaseEntry
+	var warnings []model.Warning
+	for _, db := range a.sched.managedDBs() {
+		if (namespace != "" && db.Namespace != namespace) || (engine != "" && db.Engine != engine) {
+			continue
+		}
+		entry, warning := a.databaseEntry(r.Context(), db)
+		entries = append(entries, entry)
+		if warning != nil {
+			warnings = append(warnings, *warning)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Namespace != entries[j].Namespace {
+			return entries[i].Namespace < entries[j].Namespace
+		}
+		if entries[i].Name != entries[j].Name {
+			return entries[i].Name < entries[j].Name
+		}
+		return entries[i].Engine < entries[j].Engine
+	})
+
+	env := model.NewEnvelope(command, printer.NewRunID(), &model.GetDatabasesResult{Databases: entries})
+	env.WithDuration(time.Since(start))
+	env.WithWarnings(warnings...)
+	writeJSON(w, http.StatusOK, env)
+}
+
+// getDatabase handles GET /api/v1/databases/{engine}/{namespace}/{name}.
+func (a *apiServer) getDatabase(w http.ResponseWriter, r *http.Request) {
+	const command = "api-get-database"
+	start := time.Now()
+	engine, namespace, name := r.PathValue("engine"), r.PathValue("namespace"), r.PathValue("name")
+
+	if !a.admit(w, r, command, &authorizationv1.ResourceAttributes{
+		Verb: "get", Namespace: namespace, Name: v1alpha1.ManagedDatabaseName(engine, name),
+	}) {
+		return
+	}
+
+	db, ok := a.sched.lookup(engine + "/" + namespace + "/" + name)
+	if !ok {
+		writeNotManaged(w, command, engine, namespace, name)
+		return
+	}
+	entry, warning := a.databaseEntry(r.Context(), db)
+
+	env := model.NewEnvelope(command, printer.NewRunID(), &entry)
+	env.WithDuration(time.Since(start))
+	if warning != nil {
+		env.WithWarnings(*warning)
+	}
+	writeJSON(w, http.StatusOK, env)
+}
+
+// getTriage handles GET /api/v1/databases/{engine}/{namespace}/{name}/triage:
+// the full TriageResult, with per-instance assessments, of the database's
+// last scheduled or triggered triage. Results are kept in memory only, so
+// there is none until the first triage after the operator (or a new leader)
+// starts.
+func (a *apiServer) getTriage(w http.ResponseWriter, r *http.Request) {
+	const command = "api-get-triage"
+	start := time.Now()
+	engine, namespace, name := r.PathValue("engine"), r.PathValue("namespace"), r.PathValue("name")
+
+	if !a.admit(w, r, command, &authorizationv1.ResourceAttributes{
+		Verb: "get", Namespace: namespace, Name: v1alpha1.ManagedDatabaseName(engine, name),
+	}) {
+		return
+	}
+
+	db, ok := a.sched.lookup(engine + "/" + namespace + "/" + name)
+	if !ok {
+		writeNotManaged(w, command, engine, namespace, name)
+		return
+	}
+	result := a.sched.lastTriage(db.key())
+	if result == nil {
+		writeError(w, command, http.StatusNotFound, model.NewError("api.no_triage", model.CategoryUser,
+			fmt.Sprintf("no triage of %s has run since the operator started", db.key())))
+		return
+	}
+
+	env := model.NewEnvelope(command, printer.NewRunID(), result)
+	env.WithDuration(time.Since(start))
+	writeJSON(w, http.StatusOK, env)
+}
+
+// listSnapshots handles GET /api/v1/databases/{engine}/{namespace}/{name}/snapshots:
+// the database's restic snapshots in each of its repositories, newest first.
+// A repository that cannot be listed is skipped with a warning.
+func (a *apiServer) listSnapshots(w http.ResponseWriter, r *http.Request) {
+	const command = "api-get-snapshots"
+	start := time.Now()
+	engine, namespace, name := r.PathValue("engine"), r.PathValue("namespace"), r.PathValue("name")
+
+	if !a.admit(w, r, command, &authorizationv1.ResourceAttributes{
+		Verb: "get", Namespace: namespace, Name: v1alpha1.ManagedDatabaseName(engine, name),
+	}) {
+		return
+	}
+
+	db, ok := a.sched.lookup(engine + "/" + namespace + "/" + name)
+	if !ok {
+		writeNotManaged(w, command, engine, namespace, name)
+		return
+	}
+
+	type repoSnapshot struct {
+		repo string
+		snap restic.Snapshot
+	}
+	tags := map[string]string{"engine": db.Engine, "namespace": db.Namespace, "cluster": db.ClusterName}
+	var found []repoSnapshot
+	var warnings []model.Warning
+	for _, repoName := range db.Config.Repositories {
+		snapshots, err := a.snapshots(r.Context(), repoName, tags)
+		if err != nil {
+			warnings = append(warnings, model.NewWarning("api.repository_unavailable",
+				fmt.Sprintf("failed to list snapshots in repository %s: %v", repoName, err)))
+			continue
+		}
+		for _, snap := range snapshots {
+			found = append(found, repoSnapshot{repo: repoName, snap: snap})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].snap.Time.After(found[j].snap.Time)
+	})
+
+	entries := make([]model.SnapshotEntry, 0, len(found))
+	for _, f := range found {
+		tm := f.snap.TagMap()
+		entries = append(entries, model.SnapshotEntry{
+			Repository: f.repo, SnapshotID: f.snap.ShortID,
+			Type: tm["type"], Engine: tm["engine"],
+			Namespace: tm["namespace"], Cluster: tm["cluster"],
+			Age: output.FormatAge(time.Since(f.snap.Time).Truncate(time.Second)),
+		})
+	}
+
+	env := model.NewEnvelope(command, printer.NewRunID(), &model.GetBackupsResult{Snapshots: entries})
+	env.WithDuration(time.Since(start))
+	env.WithWarnings(warnings...)
+	writeJSON(w, http.StatusOK, env)
+}
+
+// snapshots lists the snapshots matching tags in one repository.
+func (a *apiServer) snapshots(ctx context.Context, repoName string, tags map[string]string) ([]restic.Snapshot, error) {
+	repository, password, env, err := a.sched.getRepoCredentials(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+	common.RegisterSecret(password)
+	return restic.NewClient(repository, password, env).Snapshots(ctx, tags)
+}
+
+// trigger handles POST /api/v1/databases/{engine}/{namespace}/{name}/{operation}
+// for the triage, backup and repair operations. The job is queued behind the
+// database's other jobs and the request returns 202 without waiting for it;
+// its outcome appears in the database's last results and Events.
+//
+// Each operation is authorized as create on its own ManagedDatabase
+// subresource (manageddatabases/triage, /backup, /repair). Backups go to
+// every configured repository, or to the one named by the repository query
+// parameter. A repair heals whatever the repairer finds unhealthy, with
+// escrow to the first repository, and is not subject to auto-repair limits.
+func (a *apiServer) trigger(w http.ResponseWriter, r *http.Request) {
+	engine, namespace, name, op := r.PathValue("engine"), r.PathValue("namespace"), r.PathValue("name"), r.PathValue("operation")
+	command := "api-" + op
+	start := time.Now()
+
+	if op != opTriage && op != opBackup && op != opRepair {
+		writeError(w, command, http.StatusNotFound, model.NewError("api.unknown_operation", model.CategoryUser,
+			fmt.Sprintf("unknown operation %q (expected triage, backup or repair)", op)))
+		return
+	}
+
+	caller, ok := a.authorize(w, r, command, &authorizationv1.ResourceAttributes{
+		Verb: "create", Namespace: namespace, Name: v1alpha1.ManagedDatabaseName(engine, name), Subresource: op,
+	})
+	if !ok {
+		return
+	}
+
+	key := engine + "/" + namespace + "/" + name
+	db, ok := a.sched.lookup(key)
+	if !ok {
+		writeNotManaged(w, command, engine, namespace, name)
+		return
+	}
+
+	result := &model.TriggerResult{Engine: engine, Namespace: namespace, Name: name, Operation: op}
+	record := func(id string, queued bool) {
+		if queued {
+			result.Queued = append(result.Queued, id)
+		} else {
+			result.Coalesced = append(result.Coalesced, id)
+		}
+	}
+	log := slog.With("engine", engine, "cluster", name, "namespace", namespace, "user", caller.Username)
+
+	switch op {
+	case opTriage:
+		record(opTriage, a.sched.enqueue(key, opTriage, "", func(ctx context.Context, current *ManagedDB) {
+			a.sched.runTriage(ctx, current)
+		}))
+	case opBackup:
+		repos := db.Config.Repositories
+		if repo := r.URL.Query().Get("repository"); repo != "" {
+			if !slices.Contains(repos, repo) {
+				writeError(w, command, http.StatusBadRequest, model.NewError("api.invalid_repository", model.CategoryValidation,
+					fmt.Sprintf("repository %q is not configured for %s", repo, key)))
+				return
+			}
+			repos = []string{repo}
+		}
+		if len(repos) == 0 {
+			writeError(w, command, http.StatusBadRequest, model.NewError("api.no_repositories", model.CategoryValidation,
+				fmt.Sprintf("%s has no repositories configured", key)))
+			return
+		}
+		for _, repo := range repos {
+			record(opBackup+"/"+repo, a.sched.enqueue(key, opBackup, repo, func(ctx context.Context, current *ManagedDB) {
+				_, _ = a.sched.executeBackup(ctx, current, repo, "dump")
+			}))
+		}
+	case opRepair:
+		label := "Repair requested via API by " + caller.Username
+		record(opRepair, a.sched.enqueue(key, opRepair, "", func(ctx context.Context, current *ManagedDB) {
+			_, _, _ = a.sched.executeRepair(ctx, current, log, repairRun{label: label})
+		}))
+	}
+	log.Info("Operation triggered via API", "operation", op, "queued", result.Queued, "coalesced", result.Coalesced)
+
+	env := model.NewEnvelope(command, printer.NewRunID(), result)
+	env.WithDuration(time.Since(start))
+	writeJSON(w, http.StatusAccepted, env)
+}
+
+// admit is authorize for handlers that do not need the caller.
+func (a *apiServer) admit(w http.ResponseWriter, r *http.Request, command string, attrs *authorizationv1.ResourceAttributes) bool {
+	_, ok := a.authorize(w, r, command, attrs)
+	return ok
+}
+
+// authorize authenticates the request's bearer token and checks that its
+// user may perform attrs on manageddatabases. On failure the error response
+// has been written. Standby replicas refuse every request.
+func (a *apiServer) authorize(w http.ResponseWriter, r *http.Request, command string, attrs *authorizationv1.ResourceAttributes) (*apiCaller, bool) {
+	select {
+	case <-a.elected:
+	default:
+		writeError(w, command, http.StatusServiceUnavailable, model.NewRetryableError("api.not_leader", model.CategoryTransient,
+			"this replica is not the leader"))
+		return nil, false
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		writeError(w, command, http.StatusUnauthorized, model.NewError("api.unauthenticated", model.CategoryPermission,
+			"missing bearer token"))
+		return nil, false
+	}
+
+	review := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+	if err := a.client.Create(r.Context(), review); err != nil {
+		common.WarnLog("Operator API TokenReview failed: %v", err)
+		writeError(w, command, http.StatusInternalServerError, model.NewRetryableError("api.token_review_failed", model.CategoryDependency,
+			"token review failed"))
+		return nil, false
+	}
+	if !review.Status.Authenticated {
+		writeError(w, command, http.StatusUnauthorized, model.NewError("api.unauthenticated", model.CategoryPermission,
+			"invalid bearer token"))
+		return nil, false
+	}
+	user := review.Status.User
+
+	attrs.Group = v1alpha1.GroupVersion.Group
+	attrs.Version = v1alpha1.GroupVersion.Version
+	attrs.Resource = "manageddatabases"
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	access := &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+		ResourceAttributes: attrs,
+		User:               user.Username,
+		Groups:             user.Groups,
+		UID:                user.UID,
+		Extra:              extra,
+	}}
+	if err := a.client.Create(r.Context(), access); err != nil {
+		common.WarnLog("Operator API SubjectAccessReview failed: %v", err)
+		writeError(w, command, http.StatusInternalServerError, model.NewRetryableError("api.access_review_failed", model.CategoryDependency,
+			"access review failed"))
+		return nil, false
+	}
+	if !access.Status.Allowed {
+		resource := attrs.Resource
+		if attrs.Subresource != "" {
+			resource += "/" + attrs.Subresource
+		}
+		writeError(w, command, http.StatusForbidden, model.NewError("api.forbidden", model.CategoryPermission,
+			fmt.Sprintf("user %q cannot %s %s in namespace %q", user.Username, attrs.Verb, resource, attrs.Namespace)))
+		return nil, false
+	}
+	return &apiCaller{UserInfo: user}, true
+}
+
+// databaseEntry builds the API view of a managed database from the scheduler
+// and its ManagedDatabase status. When the status cannot be read the entry
+// carries only scheduler state and a warning is returned.
+func (a *apiServer) databaseEntry(ctx context.Context, db *ManagedDB) (model.DatabaseEntry, *model.Warning) {
+	key := db.key()
+	entry := model.DatabaseEntry{
+		Engine:         db.Engine,
+		Namespace:      db.Namespace,
+		Name:           db.ClusterName,
+		Policy:         db.Config.PolicyName,
+		Mode:           db.Config.Mode,
+		Repositories:   db.Config.Repositories,
+		BackupSchedule: db.Config.BackupSchedule,
+		TriageSchedule: db.Config.TriageSchedule,
+		PruneSchedule:  db.Config.PruneSchedule,
+		Queued:         a.sched.queue.pendingIDs(key),
+	}
+	next := a.sched.nextRuns(key)
+	entry.NextRuns = model.DatabaseNextRuns{
+		Backup: timeOf(next.Backup),
+		Triage: timeOf(next.Triage),
+		Prune:  timeOf(next.Prune),
+	}
+
+	md, err := a.sched.managedDatabase(ctx, db)
+	if err != nil {
+		w := model.NewWarning("api.status_unavailable", fmt.Sprintf("ManagedDatabase status of %s unavailable: %v", key, err))
+		return entry, &w
+	}
+	st := &md.Status
+	if t := st.Triage; t != nil {
+		entry.LastTriage = &model.DatabaseTriage{
+			Time:              t.Time.Time,
+			Result:            t.Result,
+			ReadyCount:        t.ReadyCount,
+			TotalCount:        t.TotalCount,
+			ClusterPhase:      t.ClusterPhase,
+			AuthorityStatus:   t.AuthorityStatus,
+			RecommendedDonor:  t.RecommendedDonor,
+			SplitBrainDetails: t.SplitBrainDetails,
+			RPOViolations:     t.RPOViolations,
+		}
+	}
+	for _, b := range st.Backups {
+		backup := model.DatabaseBackup{
+			Repository: b.Repository,
+			Time:       b.LastBackup.Time,
+			Result:     b.Result,
+			Duration:   b.Duration,
+			Error:      b.LastError,
+		}
+		if len(b.Snapshots) > 0 {
+			backup.SnapshotID = b.Snapshots[0].ID
+		}
+		entry.LastBackups = append(entry.LastBackups, backup)
+	}
+	if rp := st.Repair; rp != nil {
+		entry.LastRepair = &model.DatabaseRepair{
+			Time:            rp.Time.Time,
+			Result:          rp.Result,
+			HealedInstances: rp.HealedInstances,
+			Duration:        rp.Duration,
+			Error:           rp.Error,
+		}
+	}
+	if g := st.RepairGuard; g != nil {
+		entry.RepairCircuitOpen = g.CircuitOpen
+		for _, t := range g.RecentRepairs {
+			entry.RecentRepairs = append(entry.RecentRepairs, t.Time)
+		}
+	}
+	return entry, nil
+}
+
+// timeOf unwraps an optional metav1.Time.
+func timeOf(t *metav1.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	return &t.Time
+}
+
+// writeNotManaged writes the 404 for a database the scheduler does not know.
+func writeNotManaged(w http.ResponseWriter, command, engine, namespace, name string) {
+	writeError(w, command, http.StatusNotFound, model.NewError("api.not_found", model.CategoryUser,
+		fmt.Sprintf("%s %s/%s is not managed by hasteward", engine, namespace, name)))
+}
+
+// writeError writes a failed envelope with one structured error.
+func writeError(w http.ResponseWriter, command string, status int, sErr model.StructuredError) {
+	exitCode := model.ExitGenericFailure
+	if status == http.StatusBadRequest {
+		exitCode = model.ExitInvalidUsage
+	}
+	env := model.NewEnvelope[any](command, printer.NewRunID(), nil)
+	env.WithErrors(exitCode, sErr)
+	writeJSON(w, status, env)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		common.DebugLog("Failed to write API response: %v", err)
+	}
+}
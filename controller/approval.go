@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// errApprovalWebhookRequired is returned for every approval-gated object
+// while approvals cannot be verified.
+var errApprovalWebhookRequired = errors.New("approval webhook required: approvals cannot be verified without the hasteward approval webhook (hasteward serve --webhook with deploy/webhook installed)")
+
+// approval returns the approver named by the approved-by annotation on obj.
+// An empty approver with a nil error means the object is not approved yet.
+//
+// Approvals are only honoured when verified: the admission webhook has
+// stamped both the requester and the approver from the authenticated users
+// of the create and the approving update (see approvalStamper), so they only
+// need to differ. Annotations alone cannot tell people apart, so without the
+// webhook nothing is approved.
+func approval(obj client.Object, verified bool) (string, error) {
+	if !verified {
+		return "", errApprovalWebhookRequired
+	}
+	approver := obj.GetAnnotations()[v1alpha1.AnnotationApprovedBy]
+	if approver == "" {
+		return "", nil
+	}
+	requester := obj.GetAnnotations()[v1alpha1.AnnotationRequestedBy]
+	if requester == "" {
+		return "", fmt.Errorf("%s is missing: %s was not created through the hasteward admission webhook; recreate it",
+			v1alpha1.AnnotationRequestedBy, obj.GetName())
+	}
+	if requester == approver {
+		return "", fmt.Errorf("approved by its own requester %s", requester)
+	}
+	return approver, nil
+}
+
+// approvalMessage is the status message of an object approval returned no
+// approver for.
+func approvalMessage(err error) string {
+	switch {
+	case err == nil:
+		return "waiting for approval: annotate with " + v1alpha1.AnnotationApprovedBy
+	case errors.Is(err, errApprovalWebhookRequired):
+		return err.Error()
+	default:
+		return "approval rejected: " + err.Error()
+	}
+}
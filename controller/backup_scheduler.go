@@ -2,71 +2,78 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 
 	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
 	"github.com/PrPlanIT/HASteward/src/common"
 	"github.com/PrPlanIT/HASteward/src/engine"
 	"github.com/PrPlanIT/HASteward/src/engine/backup"
 	"github.com/PrPlanIT/HASteward/src/engine/provider"
+	"github.com/PrPlanIT/HASteward/src/events"
 	"github.com/PrPlanIT/HASteward/src/metrics"
-)
+	"github.com/PrPlanIT/HASteward/src/output"
+	"github.com/PrPlanIT/HASteward/src/output/model"
 
-// runBackup is called by the cron scheduler to back up a database to a specific repository.
-func (s *Scheduler) runBackup(db *ManagedDB, repoName string) {
-	ctx := context.Background()
-	log := slog.With("engine", db.Engine, "cluster", db.ClusterName, "namespace", db.Namespace, "repository", repoName)
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
-	log.Info("Starting scheduled backup")
+// nativeBackupTarget is the repository label for native (CNPG
+// barmanObjectStore) backups, which bypass restic repositories.
+const nativeBackupTarget = "native"
 
-	// Fetch repository credentials
-	repository, password, envVars, err := s.getRepoCredentials(ctx, repoName)
-	if err != nil {
-		log.Error("Failed to get repository credentials", "error", err)
+// runBackup is called by the cron scheduler to back up a database to a specific repository.
+func (s *Scheduler) runBackup(ctx context.Context, db *ManagedDB, repoName string) {
+	if s.deferBackupForBlackout(db, repoName) {
 		return
 	}
-	common.RegisterSecret(password)
-
-	// Build engine config
-	cfg := &common.Config{
-		Engine:         db.Engine,
-		ClusterName:    db.ClusterName,
-		Namespace:      db.Namespace,
-		Mode:           "backup",
-		BackupsPath:    repository,
-		ResticPassword: password,
-		BackupMethod:   "dump",
-		HealTimeout:    db.Config.HealTimeout,
-		DeleteTimeout:  db.Config.DeleteTimeout,
-	}
-
-	// Set restic env vars (S3 credentials)
-	_ = envVars // TODO: pass env vars to restic client when Config supports it
-
-	// Get and validate provider
-	prov, err := provider.GetProvider(cfg.Engine)
-	if err != nil {
-		log.Error("Engine not found", "error", err)
+	if _, err := s.executeBackup(ctx, db, repoName, "dump"); err != nil {
 		return
 	}
-	if err := prov.Validate(ctx, cfg); err != nil {
-		log.Error("Engine validation failed", "error", err)
-		return
+
+	// Enforce retention right after the backup unless a dedicated prune schedule exists
+	if db.Config.PruneSchedule == "" {
+		s.runRetention(ctx, db, repoName)
 	}
+}
 
-	// Get backer and run
-	backer, err := backup.Get(prov)
-	if err != nil {
-		log.Error("Backer not found", "error", err)
-		return
+// executeBackup backs up a database once and records the outcome in metrics,
+// events and (for restic repositories) the ManagedDatabase status. It is
+// shared by scheduled backups and BackupRuns. method is "dump" or "native";
+// native backups ignore repoName.
+func (s *Scheduler) executeBackup(ctx context.Context, db *ManagedDB, repoName, method string) (*model.BackupResult, error) {
+	if method == "native" {
+		repoName = nativeBackupTarget
 	}
+	log := slog.With("engine", db.Engine, "cluster", db.ClusterName, "namespace", db.Namespace, "repository", repoName)
 
-	result, err := backup.Run(ctx, backer, engine.NopSink{})
+	result, err := s.backupOnce(ctx, log, db, repoName, method)
+	if errors.Is(err, errShuttingDown) {
+		log.Info("Backup not started", "reason", err)
+		return nil, err
+	}
 	if err != nil {
+		// An interrupted backup is still recorded, so use a context that
+		// survives the cancelled job
+		ctx, cancel := engine.CleanupContext(ctx)
+		defer cancel()
 		log.Error("Backup failed", "error", err)
 		metrics.RecordBackupFailure(db.Engine, db.ClusterName, db.Namespace, repoName)
-		s.updateStatusAnnotation(ctx, db, v1alpha1.AnnotationLastBackup, nowRFC3339())
-		return
+		s.recordEvent(ctx, db, corev1.EventTypeWarning,
+			events.ReasonBackupFailed, nil, "Backup to repository %s failed: %v", repoName, err)
+		if method != "native" {
+			s.updateStatus(ctx, db, func(st *v1alpha1.ManagedDatabaseStatus) {
+				b := repositoryBackup(st, repoName)
+				b.LastBackup = metav1.Now()
+				b.Result = resultFailed
+				b.Duration = ""
+				b.LastError = err.Error()
+			})
+		}
+		return nil, err
 	}
 
 	log.Info("Backup completed",
@@ -75,10 +82,87 @@ func (s *Scheduler) runBackup(db *ManagedDB, repoName string) {
 		"duration", result.Duration.String())
 
 	metrics.RecordBackupSuccess(db.Engine, db.ClusterName, db.Namespace, repoName, result)
+	s.recordEvent(ctx, db, corev1.EventTypeNormal,
+		events.ReasonBackupSucceeded, result, "Backed up to repository %s: snapshot %s (%s in %s)",
+		repoName, result.SnapshotID, output.FormatBytes(result.Size), result.Duration.Truncate(time.Second))
 
-	// Update status annotations
-	s.updateMultipleAnnotations(ctx, db, map[string]string{
-		v1alpha1.AnnotationLastBackup:         nowRFC3339(),
-		v1alpha1.AnnotationLastBackupDuration: result.Duration.Truncate(1e9).String(), // truncate to seconds
+	if method == "native" {
+		return result, nil
+	}
+	s.updateStatus(ctx, db, func(st *v1alpha1.ManagedDatabaseStatus) {
+		now := metav1.Now()
+		b := repositoryBackup(st, repoName)
+		b.LastBackup = now
+		b.Result = resultSucceeded
+		b.Duration = result.Duration.Truncate(time.Second).String()
+		b.LastError = ""
+		b.Snapshots = append([]v1alpha1.SnapshotStatus{{
+			ID:        result.SnapshotID,
+			Time:      now,
+			SizeBytes: result.Size,
+		}}, b.Snapshots...)
+		if len(b.Snapshots) > v1alpha1.MaxRecordedSnapshots {
+			b.Snapshots = b.Snapshots[:v1alpha1.MaxRecordedSnapshots]
+		}
 	})
+	return result, nil
+}
+
+// backupOnce waits for backup slots, resolves credentials and runs the engine backer.
+func (s *Scheduler) backupOnce(ctx context.Context, log *slog.Logger, db *ManagedDB, repoName, method string) (*model.BackupResult, error) {
+	cfg := &common.Config{
+		Engine:        db.Engine,
+		ClusterName:   db.ClusterName,
+		Namespace:     db.Namespace,
+		Mode:          "backup",
+		BackupMethod:  method,
+		HealTimeout:   db.Config.HealTimeout,
+		DeleteTimeout: db.Config.DeleteTimeout,
+	}
+
+	if method == "native" {
+		// Native backups write to the cluster's object store, not a restic
+		// repository; only the operator-wide slot applies.
+		if !s.backupSlots.acquire() {
+			return nil, errShuttingDown
+		}
+		defer s.backupSlots.release()
+	} else {
+		// Wait for a global backup slot and a slot on the repository
+		release, err := s.acquireBackup(repoName, s.repoMaxConcurrency(ctx, repoName))
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
+	log.Info("Starting backup", "method", method)
+
+	if method != "native" {
+		// Fetch repository credentials (env carries S3/B2/rest-server credentials)
+		repository, password, envVars, err := s.getRepoCredentials(ctx, repoName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repository credentials: %w", err)
+		}
+		common.RegisterSecret(password)
+		cfg.BackupsPath = repository
+		cfg.ResticPassword = password
+		cfg.ResticEnv = envVars
+	}
+
+	// Get and validate provider
+	prov, err := provider.GetProvider(cfg.Engine)
+	if err != nil {
+		return nil, fmt.Errorf("engine not found: %w", err)
+	}
+	if err := prov.Validate(ctx, cfg); err != nil {
+		return nil, fmt.Errorf("engine validation failed: %w", err)
+	}
+
+	// Get backer and run
+	backer, err := backup.Get(prov)
+	if err != nil {
+		return nil, err
+	}
+	return backup.Run(ctx, backer, engine.NopSink{})
 }
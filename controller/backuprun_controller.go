@@ -0,0 +1,252 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+	"github.com/PrPlanIT/HASteward/src/metrics"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// runRecheckInterval is how often a Pending BackupRun or RestoreRequest is
+// re-queued, covering jobs dropped when the database was deregistered.
+const runRecheckInterval = time.Minute
+
+// BackupRunReconciler validates BackupRuns and hands them to the scheduler's
+// per-database queue.
+type BackupRunReconciler struct {
+	client    client.Client
+	scheduler *Scheduler
+}
+
+// SetupBackupRunController registers the BackupRun reconciler. Status writes
+// do not trigger a reconcile; the spec is immutable, so each run is seen once
+// on creation (and again at operator startup).
+func SetupBackupRunController(mgr ctrl.Manager, sched *Scheduler) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("backuprun").
+		For(&v1alpha1.BackupRun{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(&BackupRunReconciler{
+			client:    mgr.GetClient(),
+			scheduler: sched,
+		})
+}
+
+func (r *BackupRunReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	run := &v1alpha1.BackupRun{}
+	if err := r.client.Get(ctx, req.NamespacedName, run); err != nil {
+		if errors.IsNotFound(err) {
+			metrics.RecordReconcile("backuprun", "success")
+			return ctrl.Result{}, nil
+		}
+		metrics.RecordReconcile("backuprun", "error")
+		return ctrl.Result{}, err
+	}
+
+	switch run.Status.Phase {
+	case v1alpha1.BackupRunSucceeded, v1alpha1.BackupRunFailed:
+		metrics.RecordReconcile("backuprun", "success")
+		return ctrl.Result{}, nil
+	case v1alpha1.BackupRunRunning:
+		if !r.scheduler.runActive("backuprun/" + req.String()) {
+			// Running but not executing here: the operator (or the previous
+			// leader) stopped mid-run. Whatever it wrote is unverified.
+			r.scheduler.failBackupRun(ctx, req.NamespacedName, "operator restarted while the backup was running")
+		}
+		metrics.RecordReconcile("backuprun", "success")
+		return ctrl.Result{}, nil
+	}
+
+	db, failure, err := r.scheduler.resolveManagedDB(ctx, run.Spec.Engine, run.Namespace, run.Spec.ClusterName)
+	if err != nil {
+		metrics.RecordReconcile("backuprun", "error")
+		return ctrl.Result{}, err
+	}
+	if failure != "" {
+		r.scheduler.failBackupRun(ctx, req.NamespacedName, failure)
+		metrics.RecordReconcile("backuprun", "success")
+		return ctrl.Result{}, nil
+	}
+	if db == nil {
+		metrics.RecordReconcile("backuprun", "success")
+		return ctrl.Result{RequeueAfter: registerWait}, nil
+	}
+
+	if _, err := backupRunTargets(run, db); err != nil {
+		r.scheduler.failBackupRun(ctx, req.NamespacedName, err.Error())
+		metrics.RecordReconcile("backuprun", "success")
+		return ctrl.Result{}, nil
+	}
+
+	if run.Status.Phase == "" {
+		run.Status.Phase = v1alpha1.BackupRunPending
+		if err := r.client.Status().Update(ctx, run); err != nil {
+			metrics.RecordReconcile("backuprun", "error")
+			return ctrl.Result{}, fmt.Errorf("failed to update BackupRun %s status: %w", req, err)
+		}
+	}
+
+	r.scheduler.enqueueBackupRun(db.key(), db, req.NamespacedName)
+	metrics.RecordReconcile("backuprun", "success")
+	return ctrl.Result{RequeueAfter: runRecheckInterval}, nil
+}
+
+// backupRunTargets returns the repositories a BackupRun writes to: the
+// requested ones, or all of the database's repositories. Requested
+// repositories must be configured for the database, so a BackupRun cannot
+// push data to an arbitrary repository. Native runs have a single target.
+func backupRunTargets(run *v1alpha1.BackupRun, db *ManagedDB) ([]string, error) {
+	switch run.Spec.Method {
+	case "native":
+		if db.Engine != "cnpg" {
+			return nil, fmt.Errorf("method native is only supported for cnpg")
+		}
+		return []string{nativeBackupTarget}, nil
+	case "", "dump":
+	default:
+		return nil, fmt.Errorf("unknown method %q (expected dump or native)", run.Spec.Method)
+	}
+
+	if len(run.Spec.Repositories) == 0 {
+		if len(db.Config.Repositories) == 0 {
+			return nil, fmt.Errorf("no repositories configured for %s/%s", db.Namespace, db.ClusterName)
+		}
+		return db.Config.Repositories, nil
+	}
+	var targets []string
+	for _, repo := range run.Spec.Repositories {
+		ref, ok := databaseRepository(db, repo)
+		if !ok {
+			return nil, fmt.Errorf("repository %q is not configured for %s/%s", repo, db.Namespace, db.ClusterName)
+		}
+		targets = append(targets, ref)
+	}
+	return targets, nil
+}
+
+// enqueueBackupRun queues a BackupRun behind the database's other jobs. The
+// job id is per run, so re-queuing a run that is still pending is coalesced.
+func (s *Scheduler) enqueueBackupRun(key string, db *ManagedDB, name types.NamespacedName) {
+	s.queue.enqueue(key, db, "backuprun/"+name.Name, opBackup, func() {
+		ctx := s.jobContext()
+		current, ok := s.lookup(key)
+		if !ok {
+			s.failBackupRun(ctx, name, "database was deregistered before the backup started")
+			return
+		}
+		s.runBackupRun(ctx, current, name)
+	})
+}
+
+// runBackupRun executes a Pending BackupRun, recording each repository's
+// result as it finishes.
+func (s *Scheduler) runBackupRun(ctx context.Context, db *ManagedDB, name types.NamespacedName) {
+	log := slog.With("backupRun", name.String())
+
+	if !s.claimRun("backuprun/" + name.String()) {
+		return
+	}
+	defer s.releaseRun("backuprun/" + name.String())
+
+	run := &v1alpha1.BackupRun{}
+	if err := s.rtClient.Get(ctx, name, run); err != nil {
+		if !errors.IsNotFound(err) {
+			log.Error("Failed to get BackupRun", "error", err)
+		}
+		return
+	}
+	if run.Status.Phase != v1alpha1.BackupRunPending {
+		return
+	}
+	targets, err := backupRunTargets(run, db)
+	if err != nil {
+		s.failBackupRun(ctx, name, err.Error())
+		return
+	}
+
+	// Claim the run. The update is rejected if the cached copy is stale, so
+	// a run that already finished is never started again.
+	start := metav1.Now()
+	run.Status.Phase = v1alpha1.BackupRunRunning
+	run.Status.StartTime = &start
+	if err := s.rtClient.Status().Update(ctx, run); err != nil {
+		log.Error("Failed to mark BackupRun running", "error", err)
+		return
+	}
+	log.Info("Starting BackupRun", "engine", db.Engine, "cluster", db.ClusterName, "targets", targets)
+
+	method := run.Spec.Method
+	if method == "" {
+		method = "dump"
+	}
+
+	failed := 0
+	for _, repoName := range targets {
+		res := v1alpha1.BackupRunResult{Repository: repoName}
+		result, err := s.executeBackup(ctx, db, repoName, method)
+		if err != nil {
+			res.Error = err.Error()
+			failed++
+		} else {
+			if method == "native" {
+				res.Repository = result.Repository
+			}
+			res.SnapshotID = result.SnapshotID
+			res.SizeBytes = result.Size
+			res.Duration = result.Duration.Truncate(time.Second).String()
+		}
+		s.updateBackupRun(ctx, name, func(st *v1alpha1.BackupRunStatus) {
+			st.Results = append(st.Results, res)
+		})
+	}
+
+	s.updateBackupRun(ctx, name, func(st *v1alpha1.BackupRunStatus) {
+		now := metav1.Now()
+		st.CompletionTime = &now
+		st.Duration = now.Sub(start.Time).Truncate(time.Second).String()
+		if failed > 0 {
+			st.Phase = v1alpha1.BackupRunFailed
+			st.Error = fmt.Sprintf("%d of %d backups failed", failed, len(targets))
+		} else {
+			st.Phase = v1alpha1.BackupRunSucceeded
+		}
+	})
+	log.Info("BackupRun finished", "failed", failed, "total", len(targets))
+}
+
+// failBackupRun marks a BackupRun Failed with reason.
+func (s *Scheduler) failBackupRun(ctx context.Context, name types.NamespacedName, reason string) {
+	slog.Warn("BackupRun failed", "backupRun", name.String(), "reason", reason)
+	s.updateBackupRun(ctx, name, func(st *v1alpha1.BackupRunStatus) {
+		now := metav1.Now()
+		st.Phase = v1alpha1.BackupRunFailed
+		st.CompletionTime = &now
+		st.Error = reason
+	})
+}
+
+// updateBackupRun applies mutate to a BackupRun's status, retrying on conflict.
+func (s *Scheduler) updateBackupRun(ctx context.Context, name types.NamespacedName, mutate func(*v1alpha1.BackupRunStatus)) {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		run := &v1alpha1.BackupRun{}
+		if err := s.rtClient.Get(ctx, name, run); err != nil {
+			return err
+		}
+		mutate(&run.Status)
+		return s.rtClient.Status().Update(ctx, run)
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		slog.Error("Failed to update BackupRun status", "backupRun", name.String(), "error", err)
+	}
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+	"github.com/PrPlanIT/HASteward/src/metrics"
+
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// catchUpBackups queues a backup to each repository whose latest scheduled
+// backup fell between its last recorded backup and now. The cron only fires
+// going forward, so without this a backup window spent with the operator down
+// is silently skipped. A catch-up later than the effective starting deadline
+// is skipped instead; both are counted in hasteward_scheduler_missed_runs_total.
+//
+// The last backup comes from ManagedDatabase status, falling back to the
+// legacy last-backup annotation. Repositories never backed up by the operator
+// are not caught up: a newly opted-in database waits for its schedule.
+func (s *Scheduler) catchUpBackups(ctx context.Context, obj *unstructured.Unstructured, db *ManagedDB) {
+	if db.Config.BackupSchedule == "" || db.Config.Mode == "disabled" {
+		return
+	}
+	deadline := db.Config.StartingDeadlineSeconds
+	if deadline != nil && *deadline == 0 {
+		return
+	}
+	key := db.key()
+	log := slog.With("engine", db.Engine, "cluster", db.ClusterName, "namespace", db.Namespace)
+
+	sched, err := s.splayedSchedule(key, db.Config.BackupSchedule)
+	if err != nil {
+		return // already logged by Register
+	}
+
+	var legacy time.Time
+	if v := obj.GetAnnotations()[v1alpha1.AnnotationLastBackup]; v != "" {
+		legacy, _ = time.Parse(time.RFC3339, v)
+	}
+	md, err := s.managedDatabase(ctx, db)
+	if err != nil {
+		md = &v1alpha1.ManagedDatabase{}
+	}
+
+	now := time.Now()
+	for _, repoName := range db.Config.Repositories {
+		last := legacy
+		for _, b := range md.Status.Backups {
+			if b.Repository == repoName && !b.LastBackup.IsZero() {
+				last = b.LastBackup.Time
+			}
+		}
+		if last.IsZero() {
+			continue
+		}
+		missed := lastFireBetween(sched, last, now)
+		if missed.IsZero() {
+			continue
+		}
+
+		late := now.Sub(missed).Truncate(time.Second)
+		if deadline != nil && late > time.Duration(*deadline)*time.Second {
+			log.Warn("Missed scheduled backup is past its starting deadline, not catching up",
+				"repository", repoName, "missed", missed, "late", late.String(), "startingDeadlineSeconds", *deadline)
+			metrics.RecordMissedRun(db.Engine, db.ClusterName, db.Namespace, opBackup, "skipped")
+			continue
+		}
+		log.Info("Catching up missed scheduled backup", "repository", repoName, "missed", missed, "late", late.String())
+		metrics.RecordMissedRun(db.Engine, db.ClusterName, db.Namespace, opBackup, "caught_up")
+		repo := repoName // capture
+		s.enqueue(key, opBackup, repo, func(ctx context.Context, current *ManagedDB) {
+			s.runBackup(ctx, current, repo)
+		})
+	}
+}
+
+// lastFireBetween returns the latest fire time of sched in (after, now], or
+// the zero time if there is none. The search window doubles back from now so
+// a frequent schedule with a long gap does not walk every missed fire time.
+func lastFireBetween(sched cron.Schedule, after, now time.Time) time.Time {
+	for window := time.Minute; ; window *= 2 {
+		from := now.Add(-window)
+		if !from.After(after) {
+			from = after
+		}
+		var last time.Time
+		for t := sched.Next(from); !t.IsZero() && !t.After(now); t = sched.Next(t) {
+			last = t
+		}
+		if !last.IsZero() || from.Equal(after) {
+			return last
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+	"github.com/PrPlanIT/HASteward/src/common"
+	"github.com/PrPlanIT/HASteward/src/metrics"
+	"github.com/PrPlanIT/HASteward/src/restic"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Integrity check results recorded in BackupRepositoryStatus.IntegrityCheckResult.
+const (
+	IntegrityCheckPassed = "passed"
+	IntegrityCheckFailed = "failed"
+)
+
+// restic check takes an exclusive lock, so it cannot run while backups write
+// to the repository. It waits checkLockWait for their locks, then retries
+// after checkRetryDelay, up to maxCheckRetries times before the next
+// scheduled run.
+const (
+	checkLockWait   = 10 * time.Minute
+	checkRetryDelay = 15 * time.Minute
+	maxCheckRetries = 3
+)
+
+// RegisterRepositoryCheck schedules restic check for a repository.
+// An empty schedule removes any existing entry.
+func (s *Scheduler) RegisterRepositoryCheck(repoName, schedule string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.repoChecks[repoName]; ok {
+		if existing.schedule == schedule {
+			return
+		}
+		if existing.retry != nil {
+			existing.retry.Stop()
+		}
+		s.cron.Remove(existing.id)
+		delete(s.repoChecks, repoName)
+	}
+	if schedule == "" {
+		return
+	}
+
+	id, err := s.addSplayed(repoName, schedule, func() {
+		s.runRepositoryCheck(s.jobContext(), repoName)
+	})
+	if err != nil {
+		common.ErrorLog("Failed to schedule integrity check for repo %s: %v", repoName, err)
+		return
+	}
+	s.repoChecks[repoName] = &scheduledCheck{schedule: schedule, id: id}
+	common.InfoLog("Scheduled integrity check for repo %s (%s)", repoName, schedule)
+}
+
+// DeregisterRepositoryCheck removes the check schedule for a repository.
+func (s *Scheduler) DeregisterRepositoryCheck(repoName string) {
+	s.RegisterRepositoryCheck(repoName, "")
+}
+
+// runRepositoryCheck runs restic check against a repository and records the
+// outcome on the BackupRepository status. A check that finds the repository
+// locked by a prune on the same operator is skipped rather than queued; one
+// that restic cannot lock, typically because backups are writing to it, is
+// retried later and records nothing, since the repository is not at fault.
+func (s *Scheduler) runRepositoryCheck(ctx context.Context, repoName string) {
+	log := slog.With("repository", repoName)
+
+	lock := s.repoLock(repoName)
+	if !lock.TryLock() {
+		log.Info("Repository busy, skipping integrity check")
+		metrics.RecordRepositoryCheckSkipped(repoName)
+		return
+	}
+	defer lock.Unlock()
+
+	repo, err := getRepository(ctx, s.rtClient, repoName)
+	if err != nil {
+		log.Error("Failed to get repository", "error", err)
+		return
+	}
+
+	repository, password, env, err := resolveRepoCredentials(ctx, s.rtClient, repo)
+	if err != nil {
+		log.Error("Failed to get repository credentials", "error", err)
+		return
+	}
+
+	log.Info("Starting integrity check", "readDataSubset", repo.spec.CheckReadDataSubset)
+	start := time.Now()
+	checkErr := restic.NewClient(repository, password, env).Check(ctx, repo.spec.CheckReadDataSubset, checkLockWait)
+	duration := time.Since(start)
+
+	if restic.IsLockFailure(checkErr) {
+		metrics.RecordRepositoryCheckSkipped(repoName)
+		if s.retryRepositoryCheck(repoName) {
+			log.Info("Repository locked by another restic process, retrying integrity check", "in", checkRetryDelay)
+		} else {
+			log.Warn("Repository still locked by another restic process, skipping integrity check until its next run", "error", checkErr)
+		}
+		return
+	}
+	s.resetCheckRetries(repoName)
+
+	metrics.RecordRepositoryCheck(repoName, duration, checkErr == nil)
+
+	if checkErr != nil {
+		log.Error("Integrity check failed", "error", checkErr, "duration", duration)
+	} else {
+		log.Info("Integrity check passed", "duration", duration)
+	}
+
+	// Written onto a fresh read: the repository reconciler may have refreshed
+	// the status while the check was running.
+	err = updateRepositoryStatus(ctx, s.rtClient, repoName, func(st *v1alpha1.BackupRepositoryStatus) {
+		st.LastCheck = metav1.Now()
+		if checkErr != nil {
+			st.IntegrityCheckResult = IntegrityCheckFailed
+			st.Ready = false
+			st.LastError = checkErr.Error()
+			return
+		}
+		if st.IntegrityCheckResult == IntegrityCheckFailed {
+			st.Ready = true
+			st.LastError = ""
+		}
+		st.IntegrityCheckResult = IntegrityCheckPassed
+	})
+	if err != nil {
+		log.Error("Failed to update repository status", "error", err)
+	}
+}
+
+// retryRepositoryCheck runs a locked-out check again after checkRetryDelay.
+// It reports false once maxCheckRetries consecutive attempts were locked out,
+// or when the check is no longer scheduled.
+func (s *Scheduler) retryRepositoryCheck(repoName string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	check, ok := s.repoChecks[repoName]
+	if !ok {
+		return false
+	}
+	if check.retries >= maxCheckRetries {
+		check.retries = 0
+		return false
+	}
+	check.retries++
+	if check.retry == nil {
+		check.retry = time.AfterFunc(checkRetryDelay, func() {
+			s.mu.Lock()
+			check.retry = nil
+			s.mu.Unlock()
+			if ctx := s.jobContext(); ctx.Err() == nil {
+				s.runRepositoryCheck(ctx, repoName)
+			}
+		})
+	}
+	return true
+}
+
+// resetCheckRetries clears the lock failure count once a check ran.
+func (s *Scheduler) resetCheckRetries(repoName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if check, ok := s.repoChecks[repoName]; ok {
+		check.retries = 0
+	}
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// dashboardHTML is the read-only web dashboard. It is a single page that
+// reads everything through the operator API with the user's bearer token, so
+// it shows exactly what the token's RBAC allows.
+//
+//go:embed dashboard.html
+var dashboardHTML []byte
+
+// serveDashboard serves the dashboard page. The page itself holds no data and
+// is served without authentication.
+func serveDashboard(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", "default-src 'self'; script-src 'unsafe-inline'; style-src 'unsafe-inline'; frame-ancestors 'none'")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = w.Write(dashboardHTML)
+}
@@ -5,7 +5,6 @@ import (
 
 	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
 	"github.com/PrPlanIT/HASteward/src/common"
-	"github.com/PrPlanIT/HASteward/src/k8s"
 	"github.com/PrPlanIT/HASteward/src/metrics"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -13,8 +12,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
 // DatabaseReconciler watches database CRs for hasteward annotations
@@ -27,39 +29,40 @@ type DatabaseReconciler struct {
 }
 
 // SetupControllers registers a reconciler for each supported engine type.
+// Besides the database CRs themselves, each watches policies and
+// repositories (spec changes only) and re-reconciles the databases they
+// affect, so edits take effect without touching every database.
 func SetupControllers(mgr ctrl.Manager, sched *Scheduler) error {
-	// CNPG Cluster controller
-	cnpgObj := &unstructured.Unstructured{}
-	cnpgObj.SetGroupVersionKind(schema.GroupVersionKind{
-		Group: "postgresql.cnpg.io", Version: "v1", Kind: "Cluster",
-	})
-	if err := ctrl.NewControllerManagedBy(mgr).
-		Named("cnpg").
-		For(cnpgObj).
-		Complete(&DatabaseReconciler{
-			client:    mgr.GetClient(),
-			engine:    "cnpg",
-			gvk:       cnpgObj.GroupVersionKind(),
-			scheduler: sched,
-		}); err != nil {
-		return err
+	engines := []struct {
+		name string
+		gvk  schema.GroupVersionKind
+	}{
+		// CNPG Cluster controller
+		{"cnpg", schema.GroupVersionKind{Group: "postgresql.cnpg.io", Version: "v1", Kind: "Cluster"}},
+		// MariaDB controller
+		{"galera", schema.GroupVersionKind{Group: "k8s.mariadb.com", Version: "v1alpha1", Kind: "MariaDB"}},
 	}
 
-	// MariaDB controller
-	mariaObj := &unstructured.Unstructured{}
-	mariaObj.SetGroupVersionKind(schema.GroupVersionKind{
-		Group: "k8s.mariadb.com", Version: "v1alpha1", Kind: "MariaDB",
-	})
-	if err := ctrl.NewControllerManagedBy(mgr).
-		Named("galera").
-		For(mariaObj).
-		Complete(&DatabaseReconciler{
+	for _, e := range engines {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(e.gvk)
+		r := &DatabaseReconciler{
 			client:    mgr.GetClient(),
-			engine:    "galera",
-			gvk:       mariaObj.GroupVersionKind(),
+			engine:    e.name,
+			gvk:       e.gvk,
 			scheduler: sched,
-		}); err != nil {
-		return err
+		}
+		specChanged := builder.WithPredicates(predicate.GenerationChangedPredicate{})
+		if err := ctrl.NewControllerManagedBy(mgr).
+			Named(e.name).
+			For(obj).
+			Watches(&v1alpha1.BackupPolicy{}, handler.EnqueueRequestsFromMapFunc(r.policyRequests), specChanged).
+			Watches(&v1alpha1.NamespacedBackupPolicy{}, handler.EnqueueRequestsFromMapFunc(r.policyRequests), specChanged).
+			Watches(&v1alpha1.BackupRepository{}, handler.EnqueueRequestsFromMapFunc(r.repositoryRequests), specChanged).
+			Watches(&v1alpha1.NamespacedBackupRepository{}, handler.EnqueueRequestsFromMapFunc(r.repositoryRequests), specChanged).
+			Complete(r); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -87,40 +90,90 @@ func (r *DatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		annotations = map[string]string{}
 	}
 
-	// Check for policy annotation (opt-in)
-	policyName := annotations[v1alpha1.AnnotationPolicy]
-	if policyName == "" {
-		r.scheduler.Deregister(dbKey)
-		return ctrl.Result{}, nil
-	}
-
 	// Check for exclude
 	if annotations[v1alpha1.AnnotationExclude] == "true" {
 		r.scheduler.Deregister(dbKey)
+		r.scheduler.deleteManagedDatabase(ctx, r.engine, req.Namespace, req.Name)
 		return ctrl.Result{}, nil
 	}
 
-	// Fetch BackupPolicy
-	policy := &v1alpha1.BackupPolicy{}
-	if err := r.client.Get(ctx, types.NamespacedName{Name: policyName}, policy); err != nil {
-		if errors.IsNotFound(err) {
-			logger.Info("BackupPolicy not found, skipping", "policy", policyName, "database", dbKey)
-		} else {
-			common.ErrorLog("Failed to fetch BackupPolicy %q for %s: %v", policyName, dbKey, err)
+	// Bind a policy: the policy annotation always wins; otherwise a policy
+	// selector may opt the database in
+	var (
+		policy    *boundPolicy
+		conflicts []string
+		binding   string
+	)
+	if policyName := annotations[v1alpha1.AnnotationPolicy]; policyName != "" {
+		// A NamespacedBackupPolicy in the database's namespace shadows a
+		// cluster-scoped BackupPolicy of the same name
+		policySpec, namespaced, err := r.getPolicy(ctx, req.Namespace, policyName)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				// The ManagedDatabase is kept for its history; the policy
+				// watch re-registers the database if the policy returns
+				logger.Info("BackupPolicy not found, skipping", "policy", policyName, "database", dbKey)
+				r.scheduler.deregisterMissingPolicy(ctx, dbKey, policyName)
+			} else {
+				common.ErrorLog("Failed to fetch BackupPolicy %q for %s: %v", policyName, dbKey, err)
+			}
+			return ctrl.Result{}, nil
 		}
-		return ctrl.Result{}, nil
+		policy = &boundPolicy{name: policyName, spec: policySpec}
+		if namespaced {
+			policy.namespace = req.Namespace
+		}
+		binding = bindingAnnotation
+	} else {
+		var err error
+		policy, conflicts, err = r.selectPolicy(ctx, obj)
+		if err != nil {
+			metrics.RecordReconcile(r.engine, "error")
+			return ctrl.Result{}, err
+		}
+		if policy == nil {
+			r.scheduler.Deregister(dbKey)
+			r.scheduler.deleteManagedDatabase(ctx, r.engine, req.Namespace, req.Name)
+			return ctrl.Result{}, nil
+		}
+		binding = bindingSelector
 	}
 
 	// Resolve effective config
-	effectiveCfg := v1alpha1.ParseAnnotations(annotations, &policy.Spec)
+	effectiveCfg := v1alpha1.ParseAnnotations(annotations, policy.spec)
+	effectiveCfg.PolicyName = policy.ref()
+	effectiveCfg.PolicyBinding = binding
+	repos, missingRepos, err := resolveRepositories(ctx, r.client, req.Namespace, effectiveCfg.Repositories, policy.namespace != "")
+	if err != nil {
+		metrics.RecordReconcile(r.engine, "error")
+		return ctrl.Result{}, err
+	}
+	effectiveCfg.Repositories = repos
 
 	// Register with scheduler
-	r.scheduler.Register(dbKey, &ManagedDB{
+	db := &ManagedDB{
 		Namespace:   req.Namespace,
 		ClusterName: req.Name,
 		Engine:      r.engine,
 		Config:      effectiveCfg,
-	})
+
+		MissingRepositories: missingRepos,
+	}
+	_, registered := r.scheduler.lookup(dbKey)
+	r.scheduler.Register(dbKey, db)
+
+	// Record status on the ManagedDatabase CR
+	if err := r.scheduler.ensureManagedDatabase(ctx, obj, db); err != nil {
+		common.WarnLog("Failed to ensure ManagedDatabase for %s: %v", dbKey, err)
+	}
+	r.scheduler.recordPolicyConflicts(ctx, db, conflicts)
+	r.scheduler.recordMissingRepositories(ctx, db)
+
+	// First registration in this process: backups may have been missed
+	// while no operator (or another leader) was scheduling them
+	if !registered {
+		r.scheduler.catchUpBackups(ctx, obj, db)
+	}
 
 	// Set managed annotation if not already set
 	if annotations[v1alpha1.AnnotationManaged] != "true" {
@@ -130,23 +183,25 @@ func (r *DatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		}
 	}
 
-	// Update status annotation on the database CR using unstructured + dynamic client
-	r.updateTriageAnnotation(ctx, obj, annotations, dbKey)
-
 	metrics.RecordReconcile(r.engine, "success")
 	return ctrl.Result{}, nil
 }
 
-// updateTriageAnnotation is a placeholder for writing last-triage status back.
-// Full implementation in triage_scheduler.go when triage results are available.
-func (r *DatabaseReconciler) updateTriageAnnotation(ctx context.Context, obj *unstructured.Unstructured, annotations map[string]string, dbKey string) {
-	// The GVR for patching unstructured objects via the dynamic client
-	var gvr schema.GroupVersionResource
-	switch r.engine {
-	case "cnpg":
-		gvr = k8s.CNPGClusterGVR
-	case "galera":
-		gvr = k8s.MariaDBGVR
+// getPolicy returns the spec of the named policy for a database in namespace,
+// preferring a NamespacedBackupPolicy there over the cluster-scoped
+// BackupPolicy. namespaced reports which one was found.
+func (r *DatabaseReconciler) getPolicy(ctx context.Context, namespace, name string) (spec *v1alpha1.BackupPolicySpec, namespaced bool, err error) {
+	nsPolicy := &v1alpha1.NamespacedBackupPolicy{}
+	err = r.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, nsPolicy)
+	if err == nil {
+		return &nsPolicy.Spec, true, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, false, err
+	}
+	policy := &v1alpha1.BackupPolicy{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: name}, policy); err != nil {
+		return nil, false, err
 	}
-	_ = gvr // Used by scheduler when writing status annotations back
+	return &policy.Spec, false, nil
 }
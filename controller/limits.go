@@ -0,0 +1,203 @@
+package controller
+
+import (
+	"errors"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Defaults for SchedulerOptions, used by `hasteward serve` when no flag or
+// environment variable overrides them.
+const (
+	DefaultMaxConcurrentBackups  = 2
+	DefaultMaxConcurrentTriages  = 4
+	DefaultRepositoryConcurrency = 1
+	DefaultScheduleSplay         = 5 * time.Minute
+	DefaultShutdownGracePeriod   = 2 * time.Minute
+)
+
+// SchedulerOptions bounds how much scheduled work runs at once.
+// Zero or negative limits mean unlimited.
+type SchedulerOptions struct {
+	// VerifiedApprovals trusts the requested-by and approved-by annotations
+	// stamped by the admission webhook. Without it RestoreRequests and
+	// RepairProposals are never approved. Set when the operator serves the
+	// webhook and its MutatingWebhookConfiguration is installed.
+	VerifiedApprovals bool
+
+	MaxConcurrentBackups int
+	MaxConcurrentTriages int
+	// RepositoryConcurrency caps backups writing to one repository when the
+	// BackupRepository does not set spec.maxConcurrency.
+	RepositoryConcurrency int
+	// Splay is the maximum per-database delay added to cron fire times.
+	Splay time.Duration
+	// ShutdownGracePeriod is how long Stop waits for running jobs before
+	// cancelling them. Zero means DefaultShutdownGracePeriod.
+	ShutdownGracePeriod time.Duration
+}
+
+// errShuttingDown is returned by jobs that were still waiting for a slot when
+// the scheduler stopped. They never started, so they are not failures.
+var errShuttingDown = errors.New("operator is shutting down")
+
+// limiter is a counting semaphore whose limit can change between acquisitions.
+// The per-repository cap comes from the BackupRepository CR, which may be
+// edited while jobs are waiting.
+type limiter struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	limit  int
+	active int
+	closed bool
+}
+
+func newLimiter(limit int) *limiter {
+	l := &limiter{limit: limit}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+// acquire blocks until a slot is free. It returns false, without taking a
+// slot, once the limiter is closed.
+func (l *limiter) acquire() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for !l.closed && l.limit > 0 && l.active >= l.limit {
+		l.cond.Wait()
+	}
+	if l.closed {
+		return false
+	}
+	l.active++
+	return true
+}
+
+func (l *limiter) release() {
+	l.mu.Lock()
+	l.active--
+	l.mu.Unlock()
+	l.cond.Broadcast()
+}
+
+// setLimit changes the limit and wakes waiters if it was raised.
+func (l *limiter) setLimit(limit int) {
+	l.mu.Lock()
+	l.limit = limit
+	l.mu.Unlock()
+	l.cond.Broadcast()
+}
+
+// close makes current and future acquire calls fail. Held slots are still
+// released normally.
+func (l *limiter) close() {
+	l.mu.Lock()
+	l.closed = true
+	l.mu.Unlock()
+	l.cond.Broadcast()
+}
+
+// splaySchedule shifts every fire time of a cron schedule by a fixed offset.
+type splaySchedule struct {
+	inner  cron.Schedule
+	offset time.Duration
+}
+
+func (s splaySchedule) Next(t time.Time) time.Time {
+	return s.inner.Next(t.Add(-s.offset)).Add(s.offset)
+}
+
+// splayOffset returns a stable offset in [0, max) derived from key, so a
+// database keeps the same slot across restarts and replicas. The offset is
+// also kept below the schedule's own period, so a splayed run never slides
+// past the next unsplayed one.
+func splayOffset(key string, max time.Duration, sched cron.Schedule) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	now := time.Now()
+	first := sched.Next(now)
+	if period := sched.Next(first).Sub(first); period > 0 && period < max {
+		max = period
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return time.Duration(h.Sum64() % uint64(max))
+}
+
+// cronParser matches cron.WithSeconds(), used by the scheduler's cron instance.
+var cronParser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// splayedSchedule parses spec and applies the deterministic splay for key.
+func (s *Scheduler) splayedSchedule(key, spec string) (cron.Schedule, error) {
+	sched, err := cronParser.Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	if offset := splayOffset(key, s.opts.Splay, sched); offset > 0 {
+		sched = splaySchedule{inner: sched, offset: offset}
+	}
+	return sched, nil
+}
+
+// addSplayed parses spec and schedules fn with the deterministic splay for key.
+func (s *Scheduler) addSplayed(key, spec string, fn func()) (cron.EntryID, error) {
+	sched, err := s.splayedSchedule(key, spec)
+	if err != nil {
+		return 0, err
+	}
+	return s.cron.Schedule(sched, cron.FuncJob(fn)), nil
+}
+
+// acquireBackup takes a global backup slot and a slot on the target
+// repository. The returned func releases both. Fails with errShuttingDown once
+// the scheduler is stopping.
+func (s *Scheduler) acquireBackup(repoName string, repoLimit int) (func(), error) {
+	if !s.backupSlots.acquire() {
+		return nil, errShuttingDown
+	}
+	repo := s.repoSlots(repoName, repoLimit)
+	if !repo.acquire() {
+		s.backupSlots.release()
+		return nil, errShuttingDown
+	}
+	return func() {
+		repo.release()
+		s.backupSlots.release()
+	}, nil
+}
+
+// repoSlots returns the limiter for a repository, updated to limit.
+// limit <= 0 falls back to SchedulerOptions.RepositoryConcurrency.
+func (s *Scheduler) repoSlots(repoName string, limit int) *limiter {
+	if limit <= 0 {
+		limit = s.opts.RepositoryConcurrency
+	}
+	s.repoLocksMu.Lock()
+	defer s.repoLocksMu.Unlock()
+	l, ok := s.repoLimiters[repoName]
+	if !ok {
+		l = newLimiter(limit)
+		l.closed = s.slotsClosed
+		s.repoLimiters[repoName] = l
+		return l
+	}
+	l.setLimit(limit)
+	return l
+}
+
+// closeSlots closes every limiter so jobs still waiting for a slot give up
+// instead of starting during shutdown.
+func (s *Scheduler) closeSlots() {
+	s.backupSlots.close()
+	s.triageSlots.close()
+	s.repoLocksMu.Lock()
+	defer s.repoLocksMu.Unlock()
+	s.slotsClosed = true
+	for _, l := range s.repoLimiters {
+		l.close()
+	}
+}
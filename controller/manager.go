@@ -3,9 +3,12 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
 	"github.com/PrPlanIT/HASteward/src/common"
+	"github.com/PrPlanIT/HASteward/src/engine"
+	"github.com/PrPlanIT/HASteward/src/events"
 	"github.com/PrPlanIT/HASteward/src/k8s"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -13,21 +16,82 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
+// leaderElectionID names the coordination Lease held by the active operator replica.
+const leaderElectionID = "hasteward-operator.clinic.hasteward.prplanit.com"
+
+// Options configures the operator started by Run.
+type Options struct {
+	Kubeconfig string
+
+	// LeaderElection makes replicas compete for a Lease; only the leader runs
+	// controllers and the cron scheduler.
+	LeaderElection bool
+	// LeaderElectionNamespace holds the Lease. Empty uses the in-cluster
+	// service account namespace.
+	LeaderElectionNamespace string
+
+	// Scheduler bounds concurrent scheduled work and sets the cron splay.
+	Scheduler SchedulerOptions
+
+	// Webhook serves the validating admission webhook on WebhookPort.
+	Webhook bool
+	// WebhookCertDir holds tls.crt and tls.key for the webhook. Empty uses
+	// the controller-runtime default.
+	WebhookCertDir string
+
+	// API serves the operator HTTP API on APIPort.
+	API bool
+	// APICertDir holds tls.crt and tls.key for the API. It is required
+	// unless APIInsecure is set.
+	APICertDir string
+	// APIInsecure serves the API over plain HTTP when APICertDir is empty.
+	// Bearer tokens then cross the network in cleartext.
+	APIInsecure bool
+	// Dashboard serves the read-only web dashboard at / on the API port.
+	// It implies API.
+	Dashboard bool
+}
+
 // Run starts the hasteward operator: controller-runtime manager + cron scheduler.
-func Run(ctx context.Context, kubeconfig string) error {
+func Run(ctx context.Context, opts Options) error {
 	// Build scheme with core types + hasteward CRDs
 	scheme := runtime.NewScheme()
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = v1alpha1.AddToScheme(scheme)
 
+	// The API accepts bearer tokens and triggers that bypass backup windows,
+	// so it never falls back to plain HTTP silently.
+	if (opts.API || opts.Dashboard) && opts.APICertDir == "" && !opts.APIInsecure {
+		return fmt.Errorf("the operator API needs a serving certificate: pass --api-cert-dir, or --api-insecure to serve plain HTTP")
+	}
+
 	// Init the k8s package (engines use global clients)
-	c, err := k8s.Init(kubeconfig)
+	c, err := k8s.Init(opts.Kubeconfig)
 	if err != nil {
 		return fmt.Errorf("kubernetes init failed: %w", err)
 	}
 
+	// The webhook server is only created when enabled; the manager would
+	// otherwise fail to start without serving certificates.
+	var webhookServer webhook.Server
+	if opts.Webhook {
+		webhookServer = webhook.NewServer(webhook.Options{
+			Port:    WebhookPort,
+			CertDir: opts.WebhookCertDir,
+		})
+	}
+
+	// The manager must outwait the scheduler's grace period and the cleanup
+	// of jobs it cancels, or in-flight repairs are cut off mid-heal.
+	grace := opts.Scheduler.ShutdownGracePeriod
+	if grace <= 0 {
+		grace = DefaultShutdownGracePeriod
+	}
+	shutdownTimeout := grace + engine.CleanupTimeout + 10*time.Second
+
 	// Create controller-runtime manager
 	mgr, err := ctrl.NewManager(c.RestConfig, ctrl.Options{
 		Scheme: scheme,
@@ -35,19 +99,78 @@ func Run(ctx context.Context, kubeconfig string) error {
 			BindAddress: ":8080",
 		},
 		HealthProbeBindAddress: ":8081",
+		WebhookServer:          webhookServer,
+
+		LeaderElection:          opts.LeaderElection,
+		LeaderElectionID:        leaderElectionID,
+		LeaderElectionNamespace: opts.LeaderElectionNamespace,
+		// Step down as soon as the manager stops so a standby can take over
+		// without waiting for the Lease to expire.
+		LeaderElectionReleaseOnCancel: true,
+		GracefulShutdownTimeout:       &shutdownTimeout,
 	})
 	if err != nil {
 		return fmt.Errorf("unable to create manager: %w", err)
 	}
 
-	// Create scheduler
-	sched := NewScheduler(mgr.GetClient())
+	// Create scheduler. It runs as a leader-only manager runnable, so cron
+	// entries fire on exactly one replica.
+	// Approvals are only trusted when the stamper is serving here and the
+	// API server routes approval-gated resources through it.
+	if opts.Webhook {
+		if err := approvalWebhookInstalled(ctx, mgr.GetAPIReader()); err != nil {
+			common.WarnLog("Approvals cannot be verified; RestoreRequests and RepairProposals will not run until the approval webhook is installed and the operator restarted: %v", err)
+		} else {
+			opts.Scheduler.VerifiedApprovals = true
+		}
+	}
+	// The deprecated core/v1 recorder, because its correlator aggregates
+	// repeats and the CLI writes core/v1 Events too
+	recorder := mgr.GetEventRecorderFor(events.Component) //nolint:staticcheck
+	sched := NewScheduler(mgr.GetClient(), recorder, opts.Scheduler)
+	if err := mgr.Add(sched); err != nil {
+		return fmt.Errorf("unable to add scheduler: %w", err)
+	}
 
 	// Register database controllers (one per engine type)
 	if err := SetupControllers(mgr, sched); err != nil {
 		return fmt.Errorf("unable to setup controllers: %w", err)
 	}
 
+	// BackupRepository status reconciler (restic stats, snapshot count, check schedule)
+	refresh := time.Duration(common.EnvInt("REPOSITORY_REFRESH_INTERVAL", int(defaultRepositoryRefreshInterval.Seconds()))) * time.Second
+	if err := SetupRepositoryController(mgr, sched, refresh); err != nil {
+		return fmt.Errorf("unable to setup repository controller: %w", err)
+	}
+
+	// On-demand BackupRuns, executed through the scheduler's job queue
+	if err := SetupBackupRunController(mgr, sched); err != nil {
+		return fmt.Errorf("unable to setup backuprun controller: %w", err)
+	}
+
+	// RestoreRequests, held until approved
+	if err := SetupRestoreRequestController(mgr, sched); err != nil {
+		return fmt.Errorf("unable to setup restorerequest controller: %w", err)
+	}
+
+	// RepairProposals, created by triage and held until approved
+	if err := SetupRepairProposalController(mgr, sched); err != nil {
+		return fmt.Errorf("unable to setup repairproposal controller: %w", err)
+	}
+
+	// Operator HTTP API (status and triggers) and dashboard, answered by the leader
+	if opts.API || opts.Dashboard {
+		api := &apiServer{sched: sched, client: mgr.GetClient(), elected: mgr.Elected(), certDir: opts.APICertDir, dashboard: opts.Dashboard}
+		if err := mgr.Add(api); err != nil {
+			return fmt.Errorf("unable to add operator API: %w", err)
+		}
+	}
+
+	// Validating admission webhook for hasteward CRDs and database annotations
+	if opts.Webhook {
+		setupWebhooks(mgr)
+	}
+
 	// Health probes
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		return fmt.Errorf("unable to setup health check: %w", err)
@@ -55,11 +178,12 @@ func Run(ctx context.Context, kubeconfig string) error {
 	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
 		return fmt.Errorf("unable to setup ready check: %w", err)
 	}
+	if opts.Webhook {
+		if err := mgr.AddReadyzCheck("webhook", webhookServer.StartedChecker()); err != nil {
+			return fmt.Errorf("unable to setup webhook ready check: %w", err)
+		}
+	}
 
-	// Start cron scheduler
-	sched.Start()
-	defer sched.Stop()
-
-	common.InfoLog("Starting hasteward operator")
+	common.InfoLog("Starting hasteward operator (leader election: %t)", opts.LeaderElection)
 	return mgr.Start(ctx)
 }
@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+	"github.com/PrPlanIT/HASteward/src/common"
+	"github.com/PrPlanIT/HASteward/src/events"
+	"github.com/PrPlanIT/HASteward/src/k8s"
+	"github.com/PrPlanIT/HASteward/src/metrics"
+	"github.com/PrPlanIT/HASteward/src/notify"
+	"github.com/PrPlanIT/HASteward/src/output/model"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// recordEvent records a Kubernetes Event on a managed database and sends it
+// to the notification channels in its effective config. result, when not
+// nil, is attached to the notification payload.
+func (s *Scheduler) recordEvent(ctx context.Context, db *ManagedDB, eventType, reason string, result any, format string, args ...any) {
+	events.RecordWith(ctx, s.recorder, db.Engine, db.Namespace, db.ClusterName, eventType, reason, format, args...)
+	if len(db.Config.NotificationChannels) == 0 {
+		return
+	}
+
+	severity := notify.SeverityInfo
+	if eventType == corev1.EventTypeWarning {
+		severity = notify.SeverityWarning
+	}
+	n := &notify.Notification{
+		Reason:   reason,
+		Severity: severity,
+		Event: model.NewEvent(model.EventNotification, "serve", "").
+			WithMessage(fmt.Sprintf(format, args...)).
+			WithResource(databaseRef(db)).
+			WithDetails(map[string]any{"engine": db.Engine, "policy": db.Config.PolicyName}),
+		Result: result,
+	}
+	for _, name := range db.Config.NotificationChannels {
+		s.sendNotification(ctx, name, n)
+	}
+}
+
+// sendNotification delivers n to one NotificationChannel if the channel
+// accepts its reason. Delivery is best effort: failures are logged and
+// counted, never returned.
+func (s *Scheduler) sendNotification(ctx context.Context, name string, n *notify.Notification) {
+	ch := &v1alpha1.NotificationChannel{}
+	if err := s.rtClient.Get(ctx, types.NamespacedName{Name: name}, ch); err != nil {
+		common.WarnLog("Notification channel %s: %v", name, err)
+		metrics.RecordNotification(name, "failure")
+		return
+	}
+	if !ch.Spec.Accepts(n.Reason, n.Severity == notify.SeverityWarning) {
+		return
+	}
+
+	cfg, err := s.notifierConfig(ctx, &ch.Spec)
+	if err == nil {
+		var notifier notify.Notifier
+		if notifier, err = notify.New(cfg); err == nil {
+			err = notifier.Notify(ctx, n)
+		}
+	}
+	if err != nil {
+		common.WarnLog("Failed to send %s notification to channel %s: %v", n.Reason, name, err)
+		metrics.RecordNotification(name, "failure")
+		return
+	}
+	common.DebugLog("Sent %s notification to channel %s", n.Reason, name)
+	metrics.RecordNotification(name, "success")
+}
+
+// notifierConfig resolves a channel's URL and headers from its Secrets.
+func (s *Scheduler) notifierConfig(ctx context.Context, spec *v1alpha1.NotificationChannelSpec) (notify.Config, error) {
+	cfg := notify.Config{Type: spec.Type, URL: spec.URL, Channel: spec.Channel, Username: spec.Username}
+
+	if ref := spec.URLSecretRef; ref != nil {
+		secret := &corev1.Secret{}
+		if err := s.rtClient.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, secret); err != nil {
+			return cfg, fmt.Errorf("URL Secret %s/%s not found: %w", ref.Namespace, ref.Name, err)
+		}
+		url, ok := secret.Data[ref.Key]
+		if !ok {
+			return cfg, fmt.Errorf("key %q not found in Secret %s/%s", ref.Key, ref.Namespace, ref.Name)
+		}
+		cfg.URL = string(url)
+		common.RegisterSecret(cfg.URL)
+	}
+
+	if ref := spec.HeadersSecretRef; ref != nil {
+		secret := &corev1.Secret{}
+		if err := s.rtClient.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, secret); err != nil {
+			return cfg, fmt.Errorf("headers Secret %s/%s not found: %w", ref.Namespace, ref.Name, err)
+		}
+		cfg.Headers = make(map[string]string, len(secret.Data))
+		for k, v := range secret.Data {
+			cfg.Headers[k] = string(v)
+			common.RegisterSecret(string(v))
+		}
+	}
+	return cfg, nil
+}
+
+// databaseRef identifies a database CR in notification payloads.
+func databaseRef(db *ManagedDB) model.ObjectRef {
+	ref := model.ObjectRef{Namespace: db.Namespace, Name: db.ClusterName}
+	switch db.Engine {
+	case "cnpg":
+		ref.APIVersion = k8s.CNPGClusterGVR.GroupVersion().String()
+		ref.Kind = "Cluster"
+	case "galera":
+		ref.APIVersion = k8s.MariaDBGVR.GroupVersion().String()
+		ref.Kind = "MariaDB"
+	}
+	return ref
+}
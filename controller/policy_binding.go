@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+	"github.com/PrPlanIT/HASteward/src/common"
+	"github.com/PrPlanIT/HASteward/src/events"
+	"github.com/PrPlanIT/HASteward/src/metrics"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Values of EffectiveConfig.PolicyBinding.
+const (
+	bindingAnnotation = "annotation"
+	bindingSelector   = "selector"
+)
+
+// boundPolicy is the policy a database resolved to.
+type boundPolicy struct {
+	namespace string // set for a NamespacedBackupPolicy
+	name      string
+	spec      *v1alpha1.BackupPolicySpec
+}
+
+// ref returns the policy name as recorded in the effective config:
+// "namespace/name" for a NamespacedBackupPolicy.
+func (p *boundPolicy) ref() string {
+	if p.namespace == "" {
+		return p.name
+	}
+	return p.namespace + "/" + p.name
+}
+
+// selectPolicy binds a database that has no policy annotation to a policy
+// whose spec.selector matches it. NamespacedBackupPolicies in the database's
+// namespace take precedence over cluster-scoped BackupPolicies. Among
+// several matches of the same kind the first by name wins and the others are
+// returned as conflicts. A nil policy means nothing selects the database.
+func (r *DatabaseReconciler) selectPolicy(ctx context.Context, obj *unstructured.Unstructured) (*boundPolicy, []string, error) {
+	namespace := obj.GetNamespace()
+	objLabels := labels.Set(obj.GetLabels())
+
+	var nsList v1alpha1.NamespacedBackupPolicyList
+	if err := r.client.List(ctx, &nsList, client.InNamespace(namespace)); err != nil {
+		return nil, nil, err
+	}
+	var matches []*boundPolicy
+	for i := range nsList.Items {
+		p := &nsList.Items[i]
+		if policySelects(p.Spec.Selector, p.Namespace+"/"+p.Name, nil, objLabels) {
+			matches = append(matches, &boundPolicy{namespace: p.Namespace, name: p.Name, spec: &p.Spec})
+		}
+	}
+
+	if len(matches) == 0 {
+		var list v1alpha1.BackupPolicyList
+		if err := r.client.List(ctx, &list); err != nil {
+			return nil, nil, err
+		}
+		var nsLabels labels.Set
+		for i := range list.Items {
+			p := &list.Items[i]
+			if p.Spec.Selector == nil {
+				continue
+			}
+			if nsLabels == nil {
+				ns := &corev1.Namespace{}
+				if err := r.client.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+					return nil, nil, err
+				}
+				nsLabels = labels.Set(ns.Labels)
+			}
+			if policySelects(p.Spec.Selector, p.Name, nsLabels, objLabels) {
+				matches = append(matches, &boundPolicy{name: p.Name, spec: &p.Spec})
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil, nil, nil
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].name < matches[j].name })
+	var conflicts []string
+	for _, p := range matches[1:] {
+		conflicts = append(conflicts, p.ref())
+	}
+	return matches[0], conflicts, nil
+}
+
+// policySelects reports whether a policy selector matches a database. A nil
+// nsLabels skips the namespace selector (NamespacedBackupPolicy). Invalid
+// selectors match nothing.
+func policySelects(sel *v1alpha1.PolicySelector, policy string, nsLabels, objLabels labels.Set) bool {
+	if sel == nil {
+		return false
+	}
+	if nsLabels != nil && !labelSelectorMatches(sel.NamespaceSelector, policy, nsLabels) {
+		return false
+	}
+	return labelSelectorMatches(sel.ObjectSelector, policy, objLabels)
+}
+
+// labelSelectorMatches evaluates one label selector; nil matches everything.
+func labelSelectorMatches(ls *metav1.LabelSelector, policy string, set labels.Set) bool {
+	if ls == nil {
+		return true
+	}
+	s, err := metav1.LabelSelectorAsSelector(ls)
+	if err != nil {
+		common.WarnLog("Policy %s has an invalid selector: %v", policy, err)
+		return false
+	}
+	return s.Matches(set)
+}
+
+// recordPolicyConflicts exports the number of conflicting policies for a
+// database and, when the set changes, records it in the ManagedDatabase
+// status and emits a PolicyConflict event.
+func (s *Scheduler) recordPolicyConflicts(ctx context.Context, db *ManagedDB, conflicts []string) {
+	metrics.RecordPolicyConflicts(db.Engine, db.ClusterName, db.Namespace, len(conflicts))
+
+	md, err := s.managedDatabase(ctx, db)
+	if err != nil || reposEqual(md.Status.PolicyConflicts, conflicts) {
+		return
+	}
+	if len(conflicts) > 0 {
+		common.WarnLog("%s/%s: policies %s also select this database; using %s",
+			db.Namespace, db.ClusterName, strings.Join(conflicts, ", "), db.Config.PolicyName)
+		s.recordEvent(ctx, db, corev1.EventTypeWarning,
+			events.ReasonPolicyConflict, nil, "Policies %s also select this database; using %s",
+			strings.Join(conflicts, ", "), db.Config.PolicyName)
+	}
+	s.updateStatus(ctx, db, func(st *v1alpha1.ManagedDatabaseStatus) {
+		st.PolicyConflicts = conflicts
+	})
+}
+
+// recordMissingRepositories records the repositories a database's policy
+// names but that do not resolve in its ManagedDatabase status, with a
+// Warning event when the set changes.
+func (s *Scheduler) recordMissingRepositories(ctx context.Context, db *ManagedDB) {
+	md, err := s.managedDatabase(ctx, db)
+	if err != nil || reposEqual(md.Status.MissingRepositories, db.MissingRepositories) {
+		return
+	}
+	if len(db.MissingRepositories) > 0 {
+		s.recordEvent(ctx, db, corev1.EventTypeWarning,
+			events.ReasonRepositoryNotFound, nil, "Repositories %s of policy %s not found or not allowed; no backups are sent to them",
+			strings.Join(db.MissingRepositories, ", "), db.Config.PolicyName)
+	}
+	s.updateStatus(ctx, db, func(st *v1alpha1.ManagedDatabaseStatus) {
+		st.MissingRepositories = db.MissingRepositories
+	})
+}
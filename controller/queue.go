@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"github.com/PrPlanIT/HASteward/src/common"
+	"github.com/PrPlanIT/HASteward/src/metrics"
+)
+
+// Operation kinds for queued jobs. Used as the metrics "operation" label.
+const (
+	opBackup  = "backup"
+	opTriage  = "triage"
+	opPrune   = "prune"
+	opRestore = "restore"
+	opRepair  = "repair"
+)
+
+// queuedJob is a unit of work waiting for its database's worker.
+type queuedJob struct {
+	id       string // coalescing identity, e.g. "backup/local-backups"
+	op       string
+	run      func()
+	enqueued time.Time
+}
+
+// dbQueue serializes every scheduled operation against one database. At most
+// one worker goroutine drains it; the worker exits when the queue is empty.
+type dbQueue struct {
+	engine, cluster, namespace string
+
+	pending []*queuedJob
+	running bool
+}
+
+// jobQueue holds one dbQueue per managed database, keyed like Scheduler.managed.
+// Repair, dumps and retention all touch the same pods or repository, so they
+// must never overlap on a single database.
+type jobQueue struct {
+	mu      sync.Mutex
+	queues  map[string]*dbQueue
+	stopped bool
+	wg      sync.WaitGroup
+}
+
+func newJobQueue() *jobQueue {
+	return &jobQueue{queues: make(map[string]*dbQueue)}
+}
+
+// enqueue adds a job for a database. If a job with the same id is already
+// pending it is not added again: two triggers that arrive while the database
+// is busy collapse into one run. Returns false if the job was coalesced or
+// the queue is stopped.
+func (q *jobQueue) enqueue(key string, db *ManagedDB, id, op string, run func()) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.stopped {
+		return false
+	}
+
+	dq, ok := q.queues[key]
+	if !ok {
+		dq = &dbQueue{engine: db.Engine, cluster: db.ClusterName, namespace: db.Namespace}
+		q.queues[key] = dq
+	}
+
+	for _, j := range dq.pending {
+		if j.id == id {
+			common.DebugLog("Coalesced %s for %s (already pending)", id, key)
+			metrics.RecordQueueCoalesced(dq.engine, dq.cluster, dq.namespace, op)
+			return false
+		}
+	}
+
+	dq.pending = append(dq.pending, &queuedJob{id: id, op: op, run: run, enqueued: time.Now()})
+	metrics.RecordQueueDepth(dq.engine, dq.cluster, dq.namespace, len(dq.pending))
+
+	if !dq.running {
+		dq.running = true
+		q.wg.Add(1)
+		go q.work(key, dq)
+	}
+	return true
+}
+
+// work runs pending jobs for one database in FIFO order until none remain.
+func (q *jobQueue) work(key string, dq *dbQueue) {
+	defer q.wg.Done()
+	for {
+		q.mu.Lock()
+		if len(dq.pending) == 0 || q.stopped {
+			dq.running = false
+			q.mu.Unlock()
+			return
+		}
+		j := dq.pending[0]
+		dq.pending = dq.pending[1:]
+		metrics.RecordQueueDepth(dq.engine, dq.cluster, dq.namespace, len(dq.pending))
+		q.mu.Unlock()
+
+		metrics.RecordQueueWait(dq.engine, j.op, time.Since(j.enqueued))
+		common.DebugLog("Running %s for %s", j.id, key)
+		j.run()
+	}
+}
+
+// pendingIDs returns the ids of the jobs waiting for a database's worker.
+func (q *jobQueue) pendingIDs(key string) []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	dq, ok := q.queues[key]
+	if !ok {
+		return nil
+	}
+	ids := make([]string, 0, len(dq.pending))
+	for _, j := range dq.pending {
+		ids = append(ids, j.id)
+	}
+	return ids
+}
+
+// remove drops pending jobs for a database. A job that is already running
+// finishes normally.
+func (q *jobQueue) remove(key string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	dq, ok := q.queues[key]
+	if !ok {
+		return
+	}
+	dq.pending = nil
+	metrics.DeleteQueueDepth(dq.engine, dq.cluster, dq.namespace)
+	if !dq.running {
+		delete(q.queues, key)
+	}
+}
+
+// stop discards all pending jobs and waits for running ones to return.
+func (q *jobQueue) stop() {
+	q.mu.Lock()
+	q.stopped = true
+	for _, dq := range q.queues {
+		dq.pending = nil
+		metrics.RecordQueueDepth(dq.engine, dq.cluster, dq.namespace, 0)
+	}
+	q.mu.Unlock()
+	q.wg.Wait()
+}
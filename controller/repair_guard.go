@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+	"github.com/PrPlanIT/HASteward/src/events"
+	"github.com/PrPlanIT/HASteward/src/metrics"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Defaults for RepairLimits fields left empty.
+const (
+	defaultMaxRepairs       = 3
+	defaultRepairWindow     = 24 * time.Hour
+	defaultRepairBackoff    = 15 * time.Minute
+	defaultMaxRepairBackoff = 6 * time.Hour
+	defaultFailureThreshold = 3
+)
+
+// Reason labels of hasteward_repair_blocked_total.
+const (
+	blockedRateLimit   = "rate_limit"
+	blockedBackoff     = "backoff"
+	blockedCircuitOpen = "circuit_open"
+)
+
+// repairLimits is a RepairLimits with defaults applied.
+type repairLimits struct {
+	maxRepairs       int
+	window           time.Duration
+	backoff          time.Duration
+	maxBackoff       time.Duration
+	failureThreshold int
+}
+
+func resolveRepairLimits(l *v1alpha1.RepairLimits) repairLimits {
+	out := repairLimits{
+		maxRepairs:       defaultMaxRepairs,
+		window:           defaultRepairWindow,
+		backoff:          defaultRepairBackoff,
+		maxBackoff:       defaultMaxRepairBackoff,
+		failureThreshold: defaultFailureThreshold,
+	}
+	if l == nil {
+		return out
+	}
+	if l.MaxRepairs > 0 {
+		out.maxRepairs = l.MaxRepairs
+	}
+	if l.FailureThreshold > 0 {
+		out.failureThreshold = l.FailureThreshold
+	}
+	out.window = durationOr(l.Window, out.window)
+	out.backoff = durationOr(l.Backoff, out.backoff)
+	out.maxBackoff = durationOr(l.MaxBackoff, out.maxBackoff)
+	return out
+}
+
+// durationOr parses a positive duration, falling back to def.
+func durationOr(s string, def time.Duration) time.Duration {
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return d
+	}
+	return def
+}
+
+// backoffAfter returns the wait after n consecutive failed repairs: the base
+// backoff doubled per further failure, capped at maxBackoff.
+func (l repairLimits) backoffAfter(n int) time.Duration {
+	d := l.backoff
+	for i := 1; i < n && d < l.maxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, l.maxBackoff)
+}
+
+// recentRepairs drops repair times before cutoff.
+func recentRepairs(times []metav1.Time, cutoff time.Time) []metav1.Time {
+	var out []metav1.Time
+	for _, t := range times {
+		if t.After(cutoff) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// repairAllowed reports whether auto-repair may run now under the database's
+// repair limits, as recorded in the ManagedDatabase status. A pending
+// reset-repair-circuit annotation is applied first. When the status cannot be
+// read the repair is skipped: the limits exist to stop runaway repairs, so
+// they fail closed.
+func (s *Scheduler) repairAllowed(ctx context.Context, db *ManagedDB, log *slog.Logger) bool {
+	md, err := s.managedDatabase(ctx, db)
+	if err != nil {
+		log.Warn("Auto-repair skipped: cannot read repair history", "error", err)
+		return false
+	}
+
+	guard := md.Status.RepairGuard
+	if _, reset := md.Annotations[v1alpha1.AnnotationResetRepairCircuit]; reset {
+		s.resetRepairGuard(ctx, db, md, log)
+		guard = nil
+	}
+	if guard == nil {
+		metrics.RecordRepairCircuit(db.Engine, db.ClusterName, db.Namespace, false)
+		return true
+	}
+	metrics.RecordRepairCircuit(db.Engine, db.ClusterName, db.Namespace, guard.CircuitOpen)
+
+	limits := resolveRepairLimits(db.Config.RepairLimits)
+	now := time.Now()
+	var reason string
+	switch {
+	case guard.CircuitOpen:
+		reason = blockedCircuitOpen
+	case guard.BackoffUntil != nil && now.Before(guard.BackoffUntil.Time):
+		reason = blockedBackoff
+	case len(recentRepairs(guard.RecentRepairs, now.Add(-limits.window))) >= limits.maxRepairs:
+		reason = blockedRateLimit
+	default:
+		return true
+	}
+	log.Warn("Auto-repair skipped by repair limits", "reason", reason,
+		"consecutiveFailures", guard.ConsecutiveFailures)
+	metrics.RecordRepairBlocked(db.Engine, db.ClusterName, db.Namespace, reason)
+	return false
+}
+
+// resetRepairGuard clears the repair history, backoff and open circuit of a
+// database and removes the reset annotation that requested it.
+func (s *Scheduler) resetRepairGuard(ctx context.Context, db *ManagedDB, md *v1alpha1.ManagedDatabase, log *slog.Logger) {
+	s.updateStatus(ctx, db, func(st *v1alpha1.ManagedDatabaseStatus) {
+		st.RepairGuard = nil
+	})
+	patch := []byte(`{"metadata":{"annotations":{"` + v1alpha1.AnnotationResetRepairCircuit + `":null}}}`)
+	if err := s.rtClient.Patch(ctx, md, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		log.Warn("Failed to remove repair circuit reset annotation", "error", err)
+	}
+	log.Info("Auto-repair limits reset")
+	s.recordEvent(ctx, db, corev1.EventTypeNormal,
+		events.ReasonRepairCircuitReset, nil, "Auto-repair limits reset by %s", v1alpha1.AnnotationResetRepairCircuit)
+}
+
+// recordRepairOutcome adds an auto-repair that started at started to the
+// database's repair history. A failure extends the backoff and, after
+// FailureThreshold consecutive failures, opens the circuit.
+func (s *Scheduler) recordRepairOutcome(ctx context.Context, db *ManagedDB, log *slog.Logger, started time.Time, repairErr error) {
+	limits := resolveRepairLimits(db.Config.RepairLimits)
+	var opened bool
+	var failures int
+	var backoffUntil time.Time
+	s.updateStatus(ctx, db, func(st *v1alpha1.ManagedDatabaseStatus) {
+		opened = false
+		g := st.RepairGuard
+		if g == nil {
+			g = &v1alpha1.RepairGuardStatus{}
+			st.RepairGuard = g
+		}
+		g.RecentRepairs = append(recentRepairs(g.RecentRepairs, started.Add(-limits.window)), metav1.NewTime(started))
+		if repairErr == nil {
+			g.ConsecutiveFailures = 0
+			g.BackoffUntil = nil
+			g.LastError = ""
+			return
+		}
+		g.ConsecutiveFailures++
+		g.LastError = repairErr.Error()
+		backoffUntil = time.Now().Add(limits.backoffAfter(g.ConsecutiveFailures))
+		g.BackoffUntil = &metav1.Time{Time: backoffUntil}
+		if !g.CircuitOpen && g.ConsecutiveFailures >= limits.failureThreshold {
+			now := metav1.Now()
+			g.CircuitOpen = true
+			g.CircuitOpenedAt = &now
+			opened = true
+		}
+		failures = g.ConsecutiveFailures
+	})
+
+	if repairErr == nil {
+		return
+	}
+	if !opened {
+		log.Warn("Auto-repair backing off after failure", "consecutiveFailures", failures, "until", backoffUntil)
+		return
+	}
+	log.Error("Auto-repair circuit opened", "consecutiveFailures", failures)
+	metrics.RecordRepairCircuit(db.Engine, db.ClusterName, db.Namespace, true)
+	s.recordEvent(ctx, db, corev1.EventTypeWarning,
+		events.ReasonRepairCircuitOpen, nil, "Auto-repair stopped after %d consecutive failures (last: %v); annotate the ManagedDatabase with %s to resume",
+		failures, repairErr, v1alpha1.AnnotationResetRepairCircuit)
+}
@@ -0,0 +1,467 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+	"github.com/PrPlanIT/HASteward/src/engine"
+	"github.com/PrPlanIT/HASteward/src/engine/repair"
+	"github.com/PrPlanIT/HASteward/src/events"
+	"github.com/PrPlanIT/HASteward/src/metrics"
+	"github.com/PrPlanIT/HASteward/src/output/model"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// RepairProposalReconciler holds RepairProposals until they are approved and
+// then hands them to the scheduler's per-database queue.
+type RepairProposalReconciler struct {
+	client    client.Client
+	scheduler *Scheduler
+}
+
+// SetupRepairProposalController registers the RepairProposal reconciler.
+// Annotation changes trigger a reconcile so approval is picked up at once.
+func SetupRepairProposalController(mgr ctrl.Manager, sched *Scheduler) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("repairproposal").
+		For(&v1alpha1.RepairProposal{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+		))).
+		Complete(&RepairProposalReconciler{
+			client:    mgr.GetClient(),
+			scheduler: sched,
+		})
+}
+
+func (r *RepairProposalReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	p := &v1alpha1.RepairProposal{}
+	if err := r.client.Get(ctx, req.NamespacedName, p); err != nil {
+		if errors.IsNotFound(err) {
+			metrics.RecordReconcile("repairproposal", "success")
+			return ctrl.Result{}, nil
+		}
+		metrics.RecordReconcile("repairproposal", "error")
+		return ctrl.Result{}, err
+	}
+
+	switch p.Status.Phase {
+	case v1alpha1.RepairProposalSucceeded, v1alpha1.RepairProposalFailed, v1alpha1.RepairProposalExpired:
+		metrics.RecordReconcile("repairproposal", "success")
+		return ctrl.Result{}, nil
+	case v1alpha1.RepairProposalRunning:
+		if !r.scheduler.runActive("repairproposal/" + req.String()) {
+			r.scheduler.failRepairProposal(ctx, req.NamespacedName,
+				"operator restarted during the repair; triage the database before retrying")
+		}
+		metrics.RecordReconcile("repairproposal", "success")
+		return ctrl.Result{}, nil
+	}
+
+	db, failure, err := r.scheduler.resolveManagedDB(ctx, p.Spec.Engine, p.Namespace, p.Spec.ClusterName)
+	if err != nil {
+		metrics.RecordReconcile("repairproposal", "error")
+		return ctrl.Result{}, err
+	}
+	if failure != "" {
+		r.scheduler.failRepairProposal(ctx, req.NamespacedName, failure)
+		metrics.RecordReconcile("repairproposal", "success")
+		return ctrl.Result{}, nil
+	}
+	if db == nil {
+		metrics.RecordReconcile("repairproposal", "success")
+		return ctrl.Result{RequeueAfter: registerWait}, nil
+	}
+
+	approver, err := approval(p, r.scheduler.opts.VerifiedApprovals)
+	var donor *int
+	if approver != "" {
+		donor, err = proposalDonor(p)
+		if err != nil {
+			approver = ""
+		}
+	}
+	if approver == "" {
+		message := approvalMessage(err)
+		if p.Status.Phase != v1alpha1.RepairProposalPending || p.Status.Message != message {
+			p.Status.Phase = v1alpha1.RepairProposalPending
+			p.Status.Message = message
+			if err := r.client.Status().Update(ctx, p); err != nil {
+				metrics.RecordReconcile("repairproposal", "error")
+				return ctrl.Result{}, fmt.Errorf("failed to update RepairProposal %s status: %w", req, err)
+			}
+		}
+		metrics.RecordReconcile("repairproposal", "success")
+		return ctrl.Result{}, nil
+	}
+
+	if p.Status.Phase != v1alpha1.RepairProposalApproved {
+		p.Status.Phase = v1alpha1.RepairProposalApproved
+		p.Status.Message = "approved by " + approver + ", queued"
+		p.Status.ApprovedBy = approver
+		p.Status.DonorInstance = donor
+		if err := r.client.Status().Update(ctx, p); err != nil {
+			metrics.RecordReconcile("repairproposal", "error")
+			return ctrl.Result{}, fmt.Errorf("failed to update RepairProposal %s status: %w", req, err)
+		}
+	}
+
+	r.scheduler.enqueueRepairProposal(db.key(), db, req.NamespacedName)
+	metrics.RecordReconcile("repairproposal", "success")
+	return ctrl.Result{RequeueAfter: runRecheckInterval}, nil
+}
+
+// proposalDonor returns the donor ordinal an approved Galera proposal heals
+// from: the approver's override, else the proposed donor. CNPG always heals
+// from its primary, so it takes no donor. An override changed after the
+// approval was stamped has not been reviewed and is rejected.
+func proposalDonor(p *v1alpha1.RepairProposal) (*int, error) {
+	override := p.Annotations[v1alpha1.AnnotationDonorOverride]
+	if approved := p.Annotations[v1alpha1.AnnotationApprovedDonor]; override != approved {
+		return nil, fmt.Errorf("%s changed after approval (approved %q, now %q); approve it again",
+			v1alpha1.AnnotationDonorOverride, approved, override)
+	}
+	if override != "" {
+		if p.Spec.Engine != "galera" {
+			return nil, fmt.Errorf("%s is only supported for galera", v1alpha1.AnnotationDonorOverride)
+		}
+		ordinal, err := strconv.Atoi(override)
+		if err != nil || ordinal < 0 {
+			return nil, fmt.Errorf("%s must be an instance ordinal, got %q", v1alpha1.AnnotationDonorOverride, override)
+		}
+		return &ordinal, nil
+	}
+	if p.Spec.Engine != "galera" {
+		return nil, nil
+	}
+	if p.Spec.Donor == nil {
+		return nil, fmt.Errorf("no donor was proposed; name one with %s", v1alpha1.AnnotationDonorOverride)
+	}
+	ordinal := p.Spec.Donor.Ordinal
+	return &ordinal, nil
+}
+
+// enqueueRepairProposal queues an approved RepairProposal behind the
+// database's other jobs.
+func (s *Scheduler) enqueueRepairProposal(key string, db *ManagedDB, name types.NamespacedName) {
+	s.queue.enqueue(key, db, "repairproposal/"+name.Name, opRepair, func() {
+		ctx := s.jobContext()
+		current, ok := s.lookup(key)
+		if !ok {
+			s.failRepairProposal(ctx, name, "database was deregistered before the repair started")
+			return
+		}
+		s.runRepairProposal(ctx, current, name)
+	})
+}
+
+// runRepairProposal executes an approved RepairProposal: one forced, targeted
+// repair per proposed instance, stopping at the first failure.
+func (s *Scheduler) runRepairProposal(ctx context.Context, db *ManagedDB, name types.NamespacedName) {
+	log := slog.With("repairProposal", name.String(), "engine", db.Engine, "cluster", db.ClusterName, "namespace", db.Namespace)
+
+	if !s.claimRun("repairproposal/" + name.String()) {
+		return
+	}
+	defer s.releaseRun("repairproposal/" + name.String())
+
+	p := &v1alpha1.RepairProposal{}
+	if err := s.rtClient.Get(ctx, name, p); err != nil {
+		if !errors.IsNotFound(err) {
+			log.Error("Failed to get RepairProposal", "error", err)
+		}
+		return
+	}
+	if p.Status.Phase != v1alpha1.RepairProposalApproved {
+		return
+	}
+	// Approval or the override may have changed while the proposal was queued
+	approver, err := approval(p, s.opts.VerifiedApprovals)
+	if approver == "" {
+		log.Info("RepairProposal no longer approved, not starting", "error", err)
+		return
+	}
+	donor, err := proposalDonor(p)
+	if err != nil {
+		log.Info("RepairProposal donor no longer valid, not starting", "error", err)
+		return
+	}
+
+	if !s.triageSlots.acquire() {
+		log.Info("RepairProposal not started", "reason", errShuttingDown)
+		return
+	}
+	defer s.triageSlots.release()
+
+	// Claim the proposal. The update is rejected if the cached copy is stale.
+	start := metav1.Now()
+	p.Status.Phase = v1alpha1.RepairProposalRunning
+	p.Status.Message = ""
+	p.Status.ApprovedBy = approver
+	p.Status.DonorInstance = donor
+	p.Status.StartTime = &start
+	if err := s.rtClient.Status().Update(ctx, p); err != nil {
+		log.Error("Failed to mark RepairProposal running", "error", err)
+		return
+	}
+	log.Info("Starting approved repair", "targets", len(p.Spec.Targets), "approvedBy", approver)
+
+	for _, t := range p.Spec.Targets {
+		if donor != nil && t.Instance == *donor {
+			log.Info("Skipping target, it is the donor", "pod", t.Pod)
+			continue
+		}
+		instance := t.Instance
+		result, _, err := s.executeRepair(ctx, db, log, repairRun{
+			label:    fmt.Sprintf("RepairProposal %s (%s, approved by %s)", name.Name, t.Pod, approver),
+			instance: &instance,
+			donor:    donor,
+			force:    true,
+		})
+		if err != nil {
+			cctx, cancel := engine.CleanupContext(ctx)
+			s.failRepairProposal(cctx, name, fmt.Sprintf("%s: %v", t.Pod, err))
+			cancel()
+			return
+		}
+		s.updateRepairProposal(ctx, name, func(st *v1alpha1.RepairProposalStatus) {
+			st.HealedInstances = append(st.HealedInstances, result.HealedInstances...)
+		})
+	}
+
+	s.updateRepairProposal(ctx, name, func(st *v1alpha1.RepairProposalStatus) {
+		now := metav1.Now()
+		st.Phase = v1alpha1.RepairProposalSucceeded
+		st.CompletionTime = &now
+	})
+	log.Info("RepairProposal finished")
+}
+
+// proposeRepair records a repair the operator will not run unattended as a
+// Pending RepairProposal owned by the database's ManagedDatabase. At most one
+// proposal per database is open at a time.
+func (s *Scheduler) proposeRepair(ctx context.Context, db *ManagedDB, log *slog.Logger, triaged *model.TriageResult, trigger string, reasons []string, selected *repair.DonorSelection) {
+	mdName := v1alpha1.ManagedDatabaseName(db.Engine, db.ClusterName)
+
+	open, err := s.openRepairProposals(ctx, db)
+	if err != nil {
+		log.Error("Failed to list RepairProposals", "error", err)
+		return
+	}
+	if len(open) > 0 {
+		log.Info("RepairProposal already open", "proposal", open[0].Name, "phase", open[0].Status.Phase)
+		return
+	}
+
+	md := &v1alpha1.ManagedDatabase{}
+	if err := s.rtClient.Get(ctx, types.NamespacedName{Namespace: db.Namespace, Name: mdName}, md); err != nil {
+		log.Warn("ManagedDatabase not available, repair not proposed", "error", err)
+		return
+	}
+
+	triageResult := "unhealthy"
+	if trigger == v1alpha1.RepairTriggerSplitBrain {
+		triageResult = "split-brain"
+	}
+	donor := proposedDonor(db.Engine, triaged, selected)
+	targets := proposedTargets(triaged, donor)
+	if len(targets) == 0 {
+		log.Warn("Repair needed but no instance can be targeted, not proposing", "trigger", trigger)
+		return
+	}
+
+	p := &v1alpha1.RepairProposal{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: mdName + "-",
+			Namespace:    db.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "hasteward",
+				v1alpha1.LabelDatabase:         mdName,
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: v1alpha1.GroupVersion.String(),
+				Kind:       "ManagedDatabase",
+				Name:       md.Name,
+				UID:        md.UID,
+			}},
+		},
+		Spec: v1alpha1.RepairProposalSpec{
+			Engine:      db.Engine,
+			ClusterName: db.ClusterName,
+			Trigger:     trigger,
+			Reasons:     reasons,
+			Targets:     targets,
+			Donor:       donor,
+			Triage: v1alpha1.TriageStatus{
+				Time:              metav1.Now(),
+				Result:            triageResult,
+				ReadyCount:        triaged.ReadyCount,
+				TotalCount:        triaged.TotalCount,
+				ClusterPhase:      triaged.ClusterPhase,
+				AuthorityStatus:   triaged.AuthorityStatus,
+				RecommendedDonor:  triaged.RecommendedDonor,
+				SplitBrainDetails: triaged.DataComparison.SplitBrainDetails,
+			},
+		},
+	}
+	if err := s.rtClient.Create(ctx, p); err != nil {
+		log.Error("Failed to create RepairProposal", "error", err)
+		return
+	}
+	// Write the first status from the created object; the cache may not
+	// have observed it yet.
+	p.Status.Phase = v1alpha1.RepairProposalPending
+	p.Status.Message = "waiting for approval: annotate with " + v1alpha1.AnnotationApprovedBy
+	if err := s.rtClient.Status().Update(ctx, p); err != nil {
+		log.Warn("Failed to update RepairProposal status", "proposal", p.Name, "error", err)
+	}
+
+	pods := make([]string, len(targets))
+	for i, t := range targets {
+		pods[i] = t.Pod
+	}
+	log.Info("Repair proposed", "proposal", p.Name, "trigger", trigger, "targets", pods)
+	s.recordEvent(ctx, db, corev1.EventTypeWarning,
+		events.ReasonRepairProposed, triaged, "Repair needs approval (%s): RepairProposal %s would heal %s",
+		trigger, p.Name, strings.Join(pods, ", "))
+}
+
+// proposedDonor describes the instance a proposed repair would sync from: the
+// donor the Galera safety gate resolved, else triage's recommendation. CNPG
+// heals from its primary.
+func proposedDonor(engine string, triaged *model.TriageResult, selected *repair.DonorSelection) *v1alpha1.ProposedDonor {
+	if selected != nil {
+		return &v1alpha1.ProposedDonor{
+			Pod:          selected.Pod,
+			Ordinal:      selected.Ordinal,
+			Mode:         selected.Mode,
+			StateComment: selected.Probe.StateComment,
+			Warnings:     selected.Probe.Warnings,
+		}
+	}
+	for _, a := range triaged.Assessments {
+		if engine == "cnpg" && a.IsPrimary {
+			return &v1alpha1.ProposedDonor{Pod: a.Pod, Ordinal: a.Instance, Mode: "primary"}
+		}
+	}
+	if engine != "galera" {
+		return nil
+	}
+	ordinal, err := strconv.Atoi(triaged.RecommendedDonor)
+	if err != nil {
+		return nil
+	}
+	for _, a := range triaged.Assessments {
+		if a.Instance == ordinal {
+			return &v1alpha1.ProposedDonor{Pod: a.Pod, Ordinal: ordinal, Mode: "recommended", StateComment: a.WsrepStateComment}
+		}
+	}
+	return nil
+}
+
+// proposedTargets returns the instances a proposed repair would heal: those
+// triage flagged, or every instance but the donor when none were flagged
+// (split-brain). The donor and a CNPG primary are never targeted.
+func proposedTargets(triaged *model.TriageResult, donor *v1alpha1.ProposedDonor) []v1alpha1.ProposedHealTarget {
+	var flagged, others []v1alpha1.ProposedHealTarget
+	for _, a := range triaged.Assessments {
+		if a.IsPrimary || (donor != nil && a.Instance == donor.Ordinal) {
+			continue
+		}
+		reason := a.Recommendation
+		if reason == "" {
+			reason = strings.Join(a.Notes, "; ")
+		}
+		t := v1alpha1.ProposedHealTarget{Pod: a.Pod, Instance: a.Instance, Reason: reason}
+		if a.NeedsHeal {
+			flagged = append(flagged, t)
+		} else {
+			others = append(others, t)
+		}
+	}
+	if len(flagged) == 0 {
+		return others
+	}
+	return flagged
+}
+
+// openRepairProposals returns the database's proposals that have not run or
+// expired yet.
+func (s *Scheduler) openRepairProposals(ctx context.Context, db *ManagedDB) ([]v1alpha1.RepairProposal, error) {
+	list := &v1alpha1.RepairProposalList{}
+	if err := s.rtClient.List(ctx, list, client.InNamespace(db.Namespace),
+		client.MatchingLabels{v1alpha1.LabelDatabase: v1alpha1.ManagedDatabaseName(db.Engine, db.ClusterName)}); err != nil {
+		return nil, err
+	}
+	var open []v1alpha1.RepairProposal
+	for _, p := range list.Items {
+		switch p.Status.Phase {
+		case "", v1alpha1.RepairProposalPending, v1alpha1.RepairProposalApproved, v1alpha1.RepairProposalRunning:
+			open = append(open, p)
+		}
+	}
+	return open, nil
+}
+
+// expireRepairProposals expires open proposals that have not started once
+// triage finds the database healthy, so a stale approval cannot wipe a
+// recovered instance.
+func (s *Scheduler) expireRepairProposals(ctx context.Context, db *ManagedDB, log *slog.Logger) {
+	open, err := s.openRepairProposals(ctx, db)
+	if err != nil {
+		log.Error("Failed to list RepairProposals", "error", err)
+		return
+	}
+	for _, p := range open {
+		if p.Status.Phase == v1alpha1.RepairProposalRunning {
+			continue
+		}
+		log.Info("Expiring RepairProposal, database is healthy", "proposal", p.Name)
+		s.updateRepairProposal(ctx, types.NamespacedName{Namespace: p.Namespace, Name: p.Name}, func(st *v1alpha1.RepairProposalStatus) {
+			now := metav1.Now()
+			st.Phase = v1alpha1.RepairProposalExpired
+			st.Message = "triage found the database healthy"
+			st.CompletionTime = &now
+		})
+	}
+}
+
+// failRepairProposal marks a RepairProposal Failed with reason.
+func (s *Scheduler) failRepairProposal(ctx context.Context, name types.NamespacedName, reason string) {
+	slog.Warn("RepairProposal failed", "repairProposal", name.String(), "reason", reason)
+	s.updateRepairProposal(ctx, name, func(st *v1alpha1.RepairProposalStatus) {
+		now := metav1.Now()
+		st.Phase = v1alpha1.RepairProposalFailed
+		st.Message = ""
+		st.CompletionTime = &now
+		st.Error = reason
+	})
+}
+
+// updateRepairProposal applies mutate to a RepairProposal's status, retrying on conflict.
+func (s *Scheduler) updateRepairProposal(ctx context.Context, name types.NamespacedName, mutate func(*v1alpha1.RepairProposalStatus)) {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		p := &v1alpha1.RepairProposal{}
+		if err := s.rtClient.Get(ctx, name, p); err != nil {
+			return err
+		}
+		mutate(&p.Status)
+		return s.rtClient.Status().Update(ctx, p)
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		slog.Error("Failed to update RepairProposal status", "repairProposal", name.String(), "error", err)
+	}
+}
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+	"github.com/PrPlanIT/HASteward/src/common"
+	"github.com/PrPlanIT/HASteward/src/metrics"
+	"github.com/PrPlanIT/HASteward/src/output"
+	"github.com/PrPlanIT/HASteward/src/restic"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// defaultRepositoryRefreshInterval is how often repository status is refreshed
+// when HASTEWARD_REPOSITORY_REFRESH_INTERVAL is not set.
+const defaultRepositoryRefreshInterval = 15 * time.Minute
+
+// RepositoryReconciler periodically opens each BackupRepository (or
+// NamespacedBackupRepository) with restic and writes snapshot count and size
+// statistics to its status subresource. It also keeps the scheduler's
+// integrity check entries in sync with spec.checkSchedule.
+type RepositoryReconciler struct {
+	client    client.Client
+	scheduler *Scheduler
+	interval  time.Duration
+}
+
+// SetupRepositoryController registers the repository status reconciler for
+// both repository kinds. Only spec changes trigger an immediate reconcile;
+// status writes do not.
+func SetupRepositoryController(mgr ctrl.Manager, sched *Scheduler, interval time.Duration) error {
+	r := &RepositoryReconciler{
+		client:    mgr.GetClient(),
+		scheduler: sched,
+		interval:  interval,
+	}
+	if err := ctrl.NewControllerManagedBy(mgr).
+		Named("backuprepository").
+		For(&v1alpha1.BackupRepository{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("namespacedbackuprepository").
+		For(&v1alpha1.NamespacedBackupRepository{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+func (r *RepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ref := repositoryRef(req.Namespace, req.Name)
+	repo := newRepositoryObject(ref)
+	if err := r.client.Get(ctx, req.NamespacedName, repo.obj); err != nil {
+		if errors.IsNotFound(err) {
+			r.scheduler.DeregisterRepositoryCheck(ref)
+			metrics.DeleteRepositoryStats(ref)
+			metrics.RecordReconcile("repository", "success")
+			return ctrl.Result{}, nil
+		}
+		metrics.RecordReconcile("repository", "error")
+		return ctrl.Result{}, err
+	}
+
+	r.scheduler.RegisterRepositoryCheck(ref, repo.spec.CheckSchedule)
+
+	// The probe can take minutes; write its fields onto a fresh read so an
+	// integrity check that finished meanwhile is not overwritten.
+	probe := r.probe(ctx, repo)
+	if err := updateRepositoryStatus(ctx, r.client, ref, probe.apply); err != nil {
+		if errors.IsNotFound(err) {
+			metrics.RecordReconcile("repository", "success")
+			return ctrl.Result{}, nil
+		}
+		metrics.RecordReconcile("repository", "error")
+		return ctrl.Result{}, fmt.Errorf("failed to update repository %s status: %w", ref, err)
+	}
+
+	metrics.RecordReconcile("repository", "success")
+	return ctrl.Result{RequeueAfter: r.interval}, nil
+}
+
+// repositoryProbe is the outcome of opening a repository with restic.
+type repositoryProbe struct {
+	err              error
+	snapshotCount    int
+	totalSize        string
+	deduplicatedSize string
+}
+
+// apply writes the probe's fields to st. The integrity check's fields are
+// left alone, but a failed check keeps the repository unready until the next
+// check passes, even though it can still be opened and listed.
+func (p repositoryProbe) apply(st *v1alpha1.BackupRepositoryStatus) {
+	st.LastProbe = metav1.Now()
+	st.Ready = false
+	if p.err != nil {
+		st.LastError = p.err.Error()
+		return
+	}
+	if st.IntegrityCheckResult != IntegrityCheckFailed {
+		st.Ready = true
+		st.LastError = ""
+	}
+	st.SnapshotCount = p.snapshotCount
+	st.TotalSize = p.totalSize
+	st.DeduplicatedSize = p.deduplicatedSize
+}
+
+// probe opens the repository and collects snapshot and size statistics.
+// Failures are reported in the returned probe rather than as errors so the
+// reconcile still records LastError and requeues on the normal interval.
+func (r *RepositoryReconciler) probe(ctx context.Context, repo *repositoryObject) repositoryProbe {
+	ref := repo.ref()
+
+	repository, password, env, err := resolveRepoCredentials(ctx, r.client, repo)
+	if err != nil {
+		common.WarnLog("Repository %s: %v", ref, err)
+		return repositoryProbe{err: err}
+	}
+	rc := restic.NewClient(repository, password, env)
+
+	snapshots, err := rc.Snapshots(ctx, nil)
+	if err != nil {
+		common.WarnLog("Repository %s: failed to list snapshots: %v", ref, err)
+		return repositoryProbe{err: err}
+	}
+
+	restoreSize, err := rc.Stats(ctx, restic.StatsModeRestoreSize)
+	if err != nil {
+		common.WarnLog("Repository %s: restic stats (restore-size) failed: %v", ref, err)
+		return repositoryProbe{err: err}
+	}
+
+	rawData, err := rc.Stats(ctx, restic.StatsModeRawData)
+	if err != nil {
+		common.WarnLog("Repository %s: restic stats (raw-data) failed: %v", ref, err)
+		return repositoryProbe{err: err}
+	}
+
+	p := repositoryProbe{
+		snapshotCount:    len(snapshots),
+		totalSize:        output.FormatBytes(restoreSize.TotalSize),
+		deduplicatedSize: output.FormatBytes(rawData.TotalSize),
+	}
+	metrics.RecordRepositoryStats(ref, len(snapshots), restoreSize.TotalSize, rawData.TotalSize)
+	common.DebugLog("Repository %s: %d snapshots, %s total, %s deduplicated",
+		ref, p.snapshotCount, p.totalSize, p.deduplicatedSize)
+	return p
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+	"github.com/PrPlanIT/HASteward/src/common"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Repositories are referenced throughout the scheduler (effective config,
+// locks, limiters, metrics) by a single string: "name" for a cluster-scoped
+// BackupRepository and "namespace/name" for a NamespacedBackupRepository.
+
+// repositoryRef returns the reference for a repository; namespace is empty
+// for cluster-scoped ones.
+func repositoryRef(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
+
+// splitRepositoryRef is the inverse of repositoryRef.
+func splitRepositoryRef(ref string) (namespace, name string) {
+	if i := strings.IndexByte(ref, '/'); i >= 0 {
+		return ref[:i], ref[i+1:]
+	}
+	return "", ref
+}
+
+// repositoryObject is a BackupRepository or NamespacedBackupRepository, with
+// its spec and status reachable regardless of kind.
+type repositoryObject struct {
+	obj       client.Object
+	namespace string // empty for cluster-scoped
+	spec      *v1alpha1.BackupRepositorySpec
+	status    *v1alpha1.BackupRepositoryStatus
+}
+
+// ref returns the repository's scheduler reference.
+func (r *repositoryObject) ref() string {
+	return repositoryRef(r.namespace, r.obj.GetName())
+}
+
+// newRepositoryObject returns an empty object of the kind ref names.
+func newRepositoryObject(ref string) *repositoryObject {
+	namespace, _ := splitRepositoryRef(ref)
+	if namespace == "" {
+		repo := &v1alpha1.BackupRepository{}
+		return &repositoryObject{obj: repo, spec: &repo.Spec, status: &repo.Status}
+	}
+	repo := &v1alpha1.NamespacedBackupRepository{}
+	return &repositoryObject{obj: repo, namespace: namespace, spec: &repo.Spec, status: &repo.Status}
+}
+
+// getRepository reads the repository a reference names.
+func getRepository(ctx context.Context, c client.Client, ref string) (*repositoryObject, error) {
+	repo := newRepositoryObject(ref)
+	namespace, name := splitRepositoryRef(ref)
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, repo.obj); err != nil {
+		if namespace == "" {
+			return nil, fmt.Errorf("BackupRepository %q not found: %w", name, err)
+		}
+		return nil, fmt.Errorf("NamespacedBackupRepository %s not found: %w", ref, err)
+	}
+	return repo, nil
+}
+
+// updateRepositoryStatus applies mutate to a freshly read repository status,
+// retrying on conflict. The repository reconciler and the integrity check
+// both write the status, each only its own fields.
+func updateRepositoryStatus(ctx context.Context, c client.Client, ref string, mutate func(*v1alpha1.BackupRepositoryStatus)) error {
+	namespace, name := splitRepositoryRef(ref)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		repo := newRepositoryObject(ref)
+		if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, repo.obj); err != nil {
+			return err
+		}
+		mutate(repo.status)
+		return c.Status().Update(ctx, repo.obj)
+	})
+}
+
+// secretNamespace returns the namespace a repository's Secret reference
+// resolves in. A NamespacedBackupRepository can only read Secrets in its own
+// namespace.
+func (r *repositoryObject) secretNamespace(refNamespace string) (string, error) {
+	if r.namespace == "" {
+		return refNamespace, nil
+	}
+	if refNamespace != "" && refNamespace != r.namespace {
+		return "", fmt.Errorf("NamespacedBackupRepository %s cannot reference Secrets in namespace %s", r.ref(), refNamespace)
+	}
+	return r.namespace, nil
+}
+
+// resolveRepositories maps the repository names in a database's effective
+// config to references. Under a NamespacedBackupPolicy a
+// NamespacedBackupRepository in the database's namespace wins over a
+// cluster-scoped BackupRepository of the same name. A cluster-scoped policy
+// only resolves cluster-scoped repositories, so a namespace owner cannot
+// redirect its backups to a repository they control. Names that resolve to no repository, or under a NamespacedBackupPolicy to a
+// cluster-scoped one that does not allow the namespace, are dropped and
+// returned as missing, so no backups are scheduled against them.
+func resolveRepositories(ctx context.Context, c client.Client, namespace string, names []string, namespacedPolicy bool) (refs, missing []string, err error) {
+	for _, name := range names {
+		if namespacedPolicy {
+			nsRepo := &v1alpha1.NamespacedBackupRepository{}
+			err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, nsRepo)
+			if err == nil {
+				refs = append(refs, repositoryRef(namespace, name))
+				continue
+			}
+			if !errors.IsNotFound(err) {
+				return nil, nil, fmt.Errorf("failed to get NamespacedBackupRepository %s/%s: %w", namespace, name, err)
+			}
+		}
+
+		repo := &v1alpha1.BackupRepository{}
+		if err := c.Get(ctx, types.NamespacedName{Name: name}, repo); err != nil {
+			if !errors.IsNotFound(err) {
+				return nil, nil, fmt.Errorf("failed to get BackupRepository %q: %w", name, err)
+			}
+			common.WarnLog("Repository %q for namespace %s not found, skipping", name, namespace)
+			missing = append(missing, name)
+			continue
+		}
+		if namespacedPolicy && !repo.Spec.AllowsNamespace(namespace) {
+			common.WarnLog("BackupRepository %q does not allow namespace %s (spec.allowedNamespaces), skipping", name, namespace)
+			missing = append(missing, name)
+			continue
+		}
+		refs = append(refs, name)
+	}
+	return refs, missing, nil
+}
+
+// databaseRepository matches a repository name given in a BackupRun or
+// RestoreRequest against the database's effective repositories and returns
+// its reference. Either the plain name or the full reference is accepted.
+func databaseRepository(db *ManagedDB, name string) (string, bool) {
+	for _, ref := range db.Config.Repositories {
+		if ref == name || ref == repositoryRef(db.Namespace, name) {
+			return ref, true
+		}
+	}
+	return "", false
+}
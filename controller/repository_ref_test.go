@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestDeletedRepositoryLeavesSchedule checks that deleting a BackupRepository
+// a cluster-scoped policy names removes its backups from the database's
+// schedule, and that recreating it is routed back to the database.
+func TestDeletedRepositoryLeavesSchedule(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	kept := &v1alpha1.BackupRepository{ObjectMeta: metav1.ObjectMeta{Name: "kept"}}
+	deleted := &v1alpha1.BackupRepository{ObjectMeta: metav1.ObjectMeta{Name: "deleted"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(kept, deleted).Build()
+
+	s := NewScheduler(c, nil, SchedulerOptions{})
+	r := &DatabaseReconciler{client: c, engine: "cnpg", scheduler: s}
+	key := "cnpg/hyrule-castle/pg-main"
+	register := func() *ManagedDB {
+		t.Helper()
+		refs, missing, err := resolveRepositories(ctx, c, "hyrule-castle", []string{"kept", "deleted"}, false)
+		if err != nil {
+			t.Fatalf("resolveRepositories: %v", err)
+		}
+		db := &ManagedDB{
+			Namespace:   "hyrule-castle",
+			ClusterName: "pg-main",
+			Engine:      "cnpg",
+			Config: &v1alpha1.EffectiveConfig{
+				PolicyName:     "nightly",
+				BackupSchedule: "0 0 2 * * *",
+				Repositories:   refs,
+			},
+			MissingRepositories: missing,
+		}
+		s.Register(key, db)
+		return db
+	}
+
+	register()
+	if got := len(s.managed[key].backupIDs); got != 2 {
+		t.Fatalf("scheduled backups before delete = %d, want 2", got)
+	}
+	if reqs := r.repositoryRequests(ctx, deleted); len(reqs) != 1 {
+		t.Fatalf("delete of a targeted repository mapped to %d databases, want 1", len(reqs))
+	}
+
+	if err := c.Delete(ctx, deleted); err != nil {
+		t.Fatal(err)
+	}
+	db := register()
+	if got := len(s.managed[key].backupIDs); got != 1 {
+		t.Fatalf("scheduled backups after delete = %d, want 1", got)
+	}
+	if !slices.Equal(db.Config.Repositories, []string{"kept"}) {
+		t.Errorf("repositories = %v, want [kept]", db.Config.Repositories)
+	}
+	if !slices.Equal(db.MissingRepositories, []string{"deleted"}) {
+		t.Errorf("missing repositories = %v, want [deleted]", db.MissingRepositories)
+	}
+	if reqs := r.repositoryRequests(ctx, deleted); len(reqs) != 1 {
+		t.Errorf("recreating a missing repository mapped to %d databases, want 1", len(reqs))
+	}
+}
+
+// TestClusterPolicyIgnoresNamespacedRepositories checks that a
+// NamespacedBackupRepository only shadows a BackupRepository of the same name
+// under a NamespacedBackupPolicy.
+func TestClusterPolicyIgnoresNamespacedRepositories(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&v1alpha1.BackupRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: "offsite"},
+			Spec:       v1alpha1.BackupRepositorySpec{AllowedNamespaces: []string{"*"}},
+		},
+		&v1alpha1.NamespacedBackupRepository{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "offsite"}},
+	).Build()
+
+	for _, tc := range []struct {
+		namespacedPolicy bool
+		want             string
+	}{
+		{namespacedPolicy: false, want: "offsite"},
+		{namespacedPolicy: true, want: "team-a/offsite"},
+	} {
+		refs, missing, err := resolveRepositories(ctx, c, "team-a", []string{"offsite"}, tc.namespacedPolicy)
+		if err != nil {
+			t.Fatalf("namespacedPolicy=%t: %v", tc.namespacedPolicy, err)
+		}
+		if !slices.Equal(refs, []string{tc.want}) || len(missing) != 0 {
+			t.Errorf("namespacedPolicy=%t: refs = %v, missing = %v, want [%s]", tc.namespacedPolicy, refs, missing, tc.want)
+		}
+	}
+}
@@ -0,0 +1,325 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+	"github.com/PrPlanIT/HASteward/src/common"
+	"github.com/PrPlanIT/HASteward/src/engine"
+	"github.com/PrPlanIT/HASteward/src/engine/provider"
+	"github.com/PrPlanIT/HASteward/src/engine/restore"
+	"github.com/PrPlanIT/HASteward/src/events"
+	"github.com/PrPlanIT/HASteward/src/metrics"
+	"github.com/PrPlanIT/HASteward/src/output"
+	"github.com/PrPlanIT/HASteward/src/output/model"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// RestoreRequestReconciler holds RestoreRequests until they are approved and
+// then hands them to the scheduler's per-database queue.
+type RestoreRequestReconciler struct {
+	client    client.Client
+	scheduler *Scheduler
+}
+
+// SetupRestoreRequestController registers the RestoreRequest reconciler.
+// Annotation changes trigger a reconcile so approval is picked up at once.
+func SetupRestoreRequestController(mgr ctrl.Manager, sched *Scheduler) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("restorerequest").
+		For(&v1alpha1.RestoreRequest{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+		))).
+		Complete(&RestoreRequestReconciler{
+			client:    mgr.GetClient(),
+			scheduler: sched,
+		})
+}
+
+func (r *RestoreRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	rr := &v1alpha1.RestoreRequest{}
+	if err := r.client.Get(ctx, req.NamespacedName, rr); err != nil {
+		if errors.IsNotFound(err) {
+			metrics.RecordReconcile("restorerequest", "success")
+			return ctrl.Result{}, nil
+		}
+		metrics.RecordReconcile("restorerequest", "error")
+		return ctrl.Result{}, err
+	}
+
+	switch rr.Status.Phase {
+	case v1alpha1.RestoreSucceeded, v1alpha1.RestoreFailed:
+		metrics.RecordReconcile("restorerequest", "success")
+		return ctrl.Result{}, nil
+	case v1alpha1.RestoreFencing, v1alpha1.RestoreStreaming, v1alpha1.RestoreUnfencing:
+		if !r.scheduler.runActive("restorerequest/" + req.String()) {
+			r.scheduler.failRestoreRequest(ctx, req.NamespacedName,
+				"operator restarted during the restore; check the database (CNPG replicas may still be fenced)")
+		}
+		metrics.RecordReconcile("restorerequest", "success")
+		return ctrl.Result{}, nil
+	}
+
+	db, failure, err := r.scheduler.resolveManagedDB(ctx, rr.Spec.Engine, rr.Namespace, rr.Spec.ClusterName)
+	if err != nil {
+		metrics.RecordReconcile("restorerequest", "error")
+		return ctrl.Result{}, err
+	}
+	if failure != "" {
+		r.scheduler.failRestoreRequest(ctx, req.NamespacedName, failure)
+		metrics.RecordReconcile("restorerequest", "success")
+		return ctrl.Result{}, nil
+	}
+	if db == nil {
+		metrics.RecordReconcile("restorerequest", "success")
+		return ctrl.Result{RequeueAfter: registerWait}, nil
+	}
+
+	if _, err := restoreRepository(rr, db); err != nil {
+		r.scheduler.failRestoreRequest(ctx, req.NamespacedName, err.Error())
+		metrics.RecordReconcile("restorerequest", "success")
+		return ctrl.Result{}, nil
+	}
+
+	approver, err := approval(rr, r.scheduler.opts.VerifiedApprovals)
+	if approver == "" {
+		message := approvalMessage(err)
+		if rr.Status.Phase != v1alpha1.RestorePendingApproval || rr.Status.Message != message {
+			rr.Status.Phase = v1alpha1.RestorePendingApproval
+			rr.Status.Message = message
+			if err := r.client.Status().Update(ctx, rr); err != nil {
+				metrics.RecordReconcile("restorerequest", "error")
+				return ctrl.Result{}, fmt.Errorf("failed to update RestoreRequest %s status: %w", req, err)
+			}
+		}
+		metrics.RecordReconcile("restorerequest", "success")
+		return ctrl.Result{}, nil
+	}
+
+	if rr.Status.Phase != v1alpha1.RestorePending {
+		rr.Status.Phase = v1alpha1.RestorePending
+		rr.Status.Message = "approved by " + approver + ", queued"
+		if err := r.client.Status().Update(ctx, rr); err != nil {
+			metrics.RecordReconcile("restorerequest", "error")
+			return ctrl.Result{}, fmt.Errorf("failed to update RestoreRequest %s status: %w", req, err)
+		}
+	}
+
+	r.scheduler.enqueueRestoreRequest(db.key(), db, req.NamespacedName)
+	metrics.RecordReconcile("restorerequest", "success")
+	return ctrl.Result{RequeueAfter: runRecheckInterval}, nil
+}
+
+// restoreRepository checks that a RestoreRequest reads from one of the
+// database's own repositories, so it cannot load another tenant's data, and
+// returns that repository's reference.
+func restoreRepository(rr *v1alpha1.RestoreRequest, db *ManagedDB) (string, error) {
+	if ref, ok := databaseRepository(db, rr.Spec.Repository); ok {
+		return ref, nil
+	}
+	return "", fmt.Errorf("repository %q is not configured for %s/%s", rr.Spec.Repository, db.Namespace, db.ClusterName)
+}
+
+// enqueueRestoreRequest queues an approved RestoreRequest behind the
+// database's other jobs.
+func (s *Scheduler) enqueueRestoreRequest(key string, db *ManagedDB, name types.NamespacedName) {
+	s.queue.enqueue(key, db, "restorerequest/"+name.Name, opRestore, func() {
+		ctx := s.jobContext()
+		current, ok := s.lookup(key)
+		if !ok {
+			s.failRestoreRequest(ctx, name, "database was deregistered before the restore started")
+			return
+		}
+		s.runRestoreRequest(ctx, current, name)
+	})
+}
+
+// runRestoreRequest executes an approved, Pending RestoreRequest.
+func (s *Scheduler) runRestoreRequest(ctx context.Context, db *ManagedDB, name types.NamespacedName) {
+	log := slog.With("restoreRequest", name.String())
+
+	if !s.claimRun("restorerequest/" + name.String()) {
+		return
+	}
+	defer s.releaseRun("restorerequest/" + name.String())
+
+	rr := &v1alpha1.RestoreRequest{}
+	if err := s.rtClient.Get(ctx, name, rr); err != nil {
+		if !errors.IsNotFound(err) {
+			log.Error("Failed to get RestoreRequest", "error", err)
+		}
+		return
+	}
+	if rr.Status.Phase != v1alpha1.RestorePending {
+		return
+	}
+	// Approval may have been withdrawn while the request was queued
+	approver, err := approval(rr, s.opts.VerifiedApprovals)
+	if approver == "" {
+		log.Info("RestoreRequest no longer approved, not starting", "error", err)
+		return
+	}
+	repoRef, err := restoreRepository(rr, db)
+	if err != nil {
+		s.failRestoreRequest(ctx, name, err.Error())
+		return
+	}
+
+	// Block retention and integrity checks on the repository so the
+	// snapshot cannot be pruned mid-restore.
+	lock := s.repoLock(repoRef)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Claim the request. The update is rejected if the cached copy is stale.
+	start := metav1.Now()
+	rr.Status.Phase = v1alpha1.RestoreFencing
+	rr.Status.Message = ""
+	rr.Status.ApprovedBy = approver
+	rr.Status.StartTime = &start
+	if err := s.rtClient.Status().Update(ctx, rr); err != nil {
+		log.Error("Failed to mark RestoreRequest started", "error", err)
+		return
+	}
+
+	snapshot := rr.Spec.Snapshot
+	if snapshot == "" {
+		snapshot = "latest"
+	}
+	log.Info("Starting restore", "engine", db.Engine, "cluster", db.ClusterName,
+		"repository", rr.Spec.Repository, "snapshot", snapshot, "approvedBy", approver)
+
+	result, err := s.restoreOnce(ctx, db, rr, repoRef, &restoreStatusSink{s: s, ctx: ctx, name: name})
+	if err != nil {
+		// An interrupted restore is still recorded, so use a context that
+		// survives the cancelled job
+		ctx, cancel := engine.CleanupContext(ctx)
+		defer cancel()
+		log.Error("Restore failed", "error", err)
+		metrics.RecordRestoreFailure(db.Engine, db.ClusterName, db.Namespace)
+		s.recordEvent(ctx, db, corev1.EventTypeWarning,
+			events.ReasonRestoreFailed, nil, "RestoreRequest %s failed: %v", name.Name, err)
+		s.failRestoreRequest(ctx, name, err.Error())
+		return
+	}
+
+	log.Info("Restore completed", "snapshot", result.SnapshotID, "bytes", result.BytesRestored, "duration", result.Duration.String())
+	metrics.RecordRestoreSuccess(db.Engine, db.ClusterName, db.Namespace, result.BytesRestored)
+	s.recordEvent(ctx, db, corev1.EventTypeNormal,
+		events.ReasonRestoreSucceeded, result, "RestoreRequest %s restored snapshot %s from %s (%s in %s, approved by %s)",
+		name.Name, result.SnapshotID, rr.Spec.Repository, output.FormatBytes(result.BytesRestored),
+		result.Duration.Truncate(time.Second), approver)
+
+	s.updateRestoreRequest(ctx, name, func(st *v1alpha1.RestoreRequestStatus) {
+		now := metav1.Now()
+		st.Phase = v1alpha1.RestoreSucceeded
+		st.CompletionTime = &now
+		st.Duration = result.Duration.Truncate(time.Second).String()
+		st.SnapshotID = result.SnapshotID
+		st.BytesRestored = result.BytesRestored
+	})
+}
+
+// restoreOnce resolves credentials and runs the engine restorer.
+func (s *Scheduler) restoreOnce(ctx context.Context, db *ManagedDB, rr *v1alpha1.RestoreRequest, repoRef string, sink *restoreStatusSink) (*model.RestoreResult, error) {
+	repository, password, envVars, err := s.getRepoCredentials(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository credentials: %w", err)
+	}
+	common.RegisterSecret(password)
+
+	cfg := &common.Config{
+		Engine:         db.Engine,
+		ClusterName:    db.ClusterName,
+		Namespace:      db.Namespace,
+		Mode:           "restore",
+		BackupsPath:    repository,
+		ResticPassword: password,
+		ResticEnv:      envVars,
+		BackupMethod:   "dump",
+		Snapshot:       rr.Spec.Snapshot,
+		InstanceNumber: rr.Spec.Instance,
+		HealTimeout:    db.Config.HealTimeout,
+		DeleteTimeout:  db.Config.DeleteTimeout,
+	}
+
+	prov, err := provider.GetProvider(cfg.Engine)
+	if err != nil {
+		return nil, fmt.Errorf("engine not found: %w", err)
+	}
+	if err := prov.Validate(ctx, cfg); err != nil {
+		return nil, fmt.Errorf("engine validation failed: %w", err)
+	}
+	restorer, err := restore.Get(prov)
+	if err != nil {
+		return nil, err
+	}
+	return restore.Run(ctx, restorer, sink)
+}
+
+// restoreStatusSink mirrors restore lifecycle steps into the RestoreRequest phase.
+type restoreStatusSink struct {
+	s    *Scheduler
+	ctx  context.Context
+	name types.NamespacedName
+}
+
+func (sink *restoreStatusSink) Step(name, status string) {
+	if status != "running" {
+		return
+	}
+	var phase string
+	switch name {
+	case "fencing":
+		phase = v1alpha1.RestoreFencing
+	case "streaming":
+		phase = v1alpha1.RestoreStreaming
+	case "unfencing":
+		phase = v1alpha1.RestoreUnfencing
+	default:
+		return
+	}
+	sink.s.updateRestoreRequest(sink.ctx, sink.name, func(st *v1alpha1.RestoreRequestStatus) {
+		st.Phase = phase
+	})
+}
+
+// failRestoreRequest marks a RestoreRequest Failed with reason.
+func (s *Scheduler) failRestoreRequest(ctx context.Context, name types.NamespacedName, reason string) {
+	slog.Warn("RestoreRequest failed", "restoreRequest", name.String(), "reason", reason)
+	s.updateRestoreRequest(ctx, name, func(st *v1alpha1.RestoreRequestStatus) {
+		now := metav1.Now()
+		st.Phase = v1alpha1.RestoreFailed
+		st.Message = ""
+		st.CompletionTime = &now
+		st.Error = reason
+	})
+}
+
+// updateRestoreRequest applies mutate to a RestoreRequest's status, retrying on conflict.
+func (s *Scheduler) updateRestoreRequest(ctx context.Context, name types.NamespacedName, mutate func(*v1alpha1.RestoreRequestStatus)) {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		rr := &v1alpha1.RestoreRequest{}
+		if err := s.rtClient.Get(ctx, name, rr); err != nil {
+			return err
+		}
+		mutate(&rr.Status)
+		return s.rtClient.Status().Update(ctx, rr)
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		slog.Error("Failed to update RestoreRequest status", "restoreRequest", name.String(), "error", err)
+	}
+}
@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+	"github.com/PrPlanIT/HASteward/src/common"
+	"github.com/PrPlanIT/HASteward/src/engine"
+	"github.com/PrPlanIT/HASteward/src/engine/provider"
+	"github.com/PrPlanIT/HASteward/src/engine/retention"
+	"github.com/PrPlanIT/HASteward/src/metrics"
+	"github.com/PrPlanIT/HASteward/src/output/model"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// runRetention applies the effective retention policy for a database to a
+// single repository. Both type=backup and type=diverged snapshots are pruned;
+// diverged snapshots are grouped by repair job (restic.ForgetGrouped).
+// Called after a successful backup, or by cron when a prune schedule is set.
+func (s *Scheduler) runRetention(ctx context.Context, db *ManagedDB, repoName string) {
+	log := slog.With("engine", db.Engine, "cluster", db.ClusterName, "namespace", db.Namespace, "repository", repoName)
+
+	keep := db.Config.Retention
+	if keep.KeepLast == 0 && keep.KeepDaily == 0 && keep.KeepWeekly == 0 && keep.KeepMonthly == 0 {
+		log.Debug("No retention policy configured, skipping prune")
+		return
+	}
+
+	log.Info("Starting scheduled retention",
+		"keepLast", keep.KeepLast,
+		"keepDaily", keep.KeepDaily,
+		"keepWeekly", keep.KeepWeekly,
+		"keepMonthly", keep.KeepMonthly)
+
+	// restic check and prune both need the exclusive repository lock
+	lock := s.repoLock(repoName)
+	lock.Lock()
+	defer lock.Unlock()
+
+	repository, password, envVars, err := s.getRepoCredentials(ctx, repoName)
+	if err != nil {
+		log.Error("Failed to get repository credentials", "error", err)
+		return
+	}
+	common.RegisterSecret(password)
+
+	cfg := &common.Config{
+		Engine:         db.Engine,
+		ClusterName:    db.ClusterName,
+		Namespace:      db.Namespace,
+		Mode:           "prune",
+		BackupsPath:    repository,
+		ResticPassword: password,
+		ResticEnv:      envVars,
+		HealTimeout:    db.Config.HealTimeout,
+		DeleteTimeout:  db.Config.DeleteTimeout,
+	}
+
+	prov, err := provider.GetProvider(cfg.Engine)
+	if err != nil {
+		log.Error("Engine not found", "error", err)
+		return
+	}
+	if err := prov.Validate(ctx, cfg); err != nil {
+		log.Error("Engine validation failed", "error", err)
+		return
+	}
+
+	retainer, err := retention.Get(prov)
+	if err != nil {
+		log.Error("Retainer not found", "error", err)
+		return
+	}
+
+	// Run backup and diverged passes separately: the "backup" pass prunes
+	// unreferenced data itself, while "all" defers pruning to the diverged pass.
+	result := &model.PruneResult{}
+	for _, snapshotType := range []string{"backup", "diverged"} {
+		opts := retention.PruneOptions{
+			Type:        snapshotType,
+			KeepLast:    keep.KeepLast,
+			KeepDaily:   keep.KeepDaily,
+			KeepWeekly:  keep.KeepWeekly,
+			KeepMonthly: keep.KeepMonthly,
+		}
+		r, err := retention.Run(ctx, retainer, opts, engine.NopSink{})
+		if err != nil {
+			log.Error("Retention failed", "type", snapshotType, "error", err)
+			metrics.RecordRetentionFailure(db.Engine, db.ClusterName, db.Namespace, repoName)
+			s.updateStatus(ctx, db, func(st *v1alpha1.ManagedDatabaseStatus) {
+				st.Prune = &v1alpha1.PruneStatus{
+					Time:       metav1.Now(),
+					Repository: repoName,
+					Result:     resultFailed,
+					Error:      err.Error(),
+				}
+			})
+			return
+		}
+		result.TotalKept += r.TotalKept
+		result.TotalRemoved += r.TotalRemoved
+	}
+
+	log.Info("Retention completed", "kept", result.TotalKept, "removed", result.TotalRemoved)
+
+	metrics.RecordRetentionSuccess(db.Engine, db.ClusterName, db.Namespace, repoName, result)
+
+	s.updateStatus(ctx, db, func(st *v1alpha1.ManagedDatabaseStatus) {
+		st.Prune = &v1alpha1.PruneStatus{
+			Time:       metav1.Now(),
+			Repository: repoName,
+			Result:     resultSucceeded,
+			Kept:       result.TotalKept,
+			Removed:    result.TotalRemoved,
+		}
+	})
+}
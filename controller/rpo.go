@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+	"github.com/PrPlanIT/HASteward/src/metrics"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// backupAgeInterval is how often the backup staleness gauges are refreshed.
+const backupAgeInterval = time.Minute
+
+// refreshBackupAges keeps hasteward_backup_age_seconds and
+// hasteward_backup_rpo_violated current for every managed database until ctx
+// is cancelled. Ages are derived from ManagedDatabase status, so they survive
+// operator restarts.
+func (s *Scheduler) refreshBackupAges(ctx context.Context) {
+	ticker := time.NewTicker(backupAgeInterval)
+	defer ticker.Stop()
+	for {
+		s.recordBackupAges(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) recordBackupAges(ctx context.Context) {
+	now := time.Now()
+	for _, db := range s.managedDBs() {
+		md, err := s.managedDatabase(ctx, db)
+		if err != nil {
+			continue
+		}
+		// Drop series for repositories no longer targeted
+		metrics.DeleteBackupAge(db.Engine, db.ClusterName, db.Namespace)
+		for _, a := range md.BackupAges(now) {
+			metrics.RecordBackupAge(db.Engine, db.ClusterName, db.Namespace, a.Repository, a.Age, a.Violated)
+		}
+	}
+}
+
+// rpoViolations describes the database's repositories currently in violation
+// of its RPO, for triage results.
+func (s *Scheduler) rpoViolations(ctx context.Context, db *ManagedDB) []string {
+	md, err := s.managedDatabase(ctx, db)
+	if err != nil {
+		return nil
+	}
+	return md.RPOViolations(time.Now())
+}
+
+// managedDatabase reads the database's ManagedDatabase.
+func (s *Scheduler) managedDatabase(ctx context.Context, db *ManagedDB) (*v1alpha1.ManagedDatabase, error) {
+	md := &v1alpha1.ManagedDatabase{}
+	name := types.NamespacedName{Namespace: db.Namespace, Name: v1alpha1.ManagedDatabaseName(db.Engine, db.ClusterName)}
+	if err := s.rtClient.Get(ctx, name, md); err != nil {
+		return nil, err
+	}
+	return md, nil
+}
@@ -8,13 +8,14 @@ import (
 
 	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
 	"github.com/PrPlanIT/HASteward/src/common"
-	"github.com/PrPlanIT/HASteward/src/k8s"
+	"github.com/PrPlanIT/HASteward/src/engine"
 	"github.com/PrPlanIT/HASteward/src/metrics"
+	"github.com/PrPlanIT/HASteward/src/output/model"
 
 	"github.com/robfig/cron/v3"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -24,42 +25,158 @@ type ManagedDB struct {
 	ClusterName string
 	Engine      string // "cnpg" or "galera"
 	Config      *v1alpha1.EffectiveConfig
+
+	// MissingRepositories are the policy's repository names that did not
+	// resolve and are left out of Config.Repositories.
+	MissingRepositories []string
+}
+
+// key returns the scheduler key for the database ("engine/namespace/name").
+func (db *ManagedDB) key() string {
+	return db.Engine + "/" + db.Namespace + "/" + db.ClusterName
 }
 
 // scheduledDB tracks the cron entries for a managed database.
 type scheduledDB struct {
 	db        *ManagedDB
 	backupIDs []cron.EntryID
+	pruneIDs  []cron.EntryID
 	triageID  cron.EntryID
+
+	lastTriageResult string              // for edge-triggered triage events
+	lastTriage       *model.TriageResult // full result of the last triage, for the dashboard
+
+	deferred map[string]*time.Timer // jobs waiting for a window, key: job id
+}
+
+// scheduledCheck tracks the cron entry for a repository integrity check.
+type scheduledCheck struct {
+	schedule string
+	id       cron.EntryID
+
+	retry   *time.Timer // pending retry of a check that found the repository locked
+	retries int         // consecutive lock failures
 }
 
 // Scheduler manages cron-based backup and triage operations for all managed databases.
 type Scheduler struct {
-	cron     *cron.Cron
-	rtClient client.Client
-	managed  map[string]*scheduledDB // key: "engine/namespace/name"
-	mu       sync.RWMutex
+	cron       *cron.Cron
+	rtClient   client.Client
+	recorder   record.EventRecorder       // aggregates repeated Events on database CRs
+	managed    map[string]*scheduledDB    // key: "engine/namespace/name"
+	repoChecks map[string]*scheduledCheck // key: BackupRepository name
+	queue      *jobQueue                  // per-database serialization of cron jobs
+	activeRuns map[string]bool            // BackupRuns/RestoreRequests executing here, key: "kind/namespace/name"
+	noPolicy   map[string]string          // databases deregistered for a missing policy, value: policy name
+	mu         sync.RWMutex
+
+	opts        SchedulerOptions
+	backupSlots *limiter // operator-wide cap on concurrent backups
+	triageSlots *limiter // operator-wide cap on concurrent triages (incl. auto-repair)
+
+	repoLocks    map[string]*sync.Mutex // serialises prune and check per repository
+	repoLimiters map[string]*limiter    // caps concurrent backups per repository
+	slotsClosed  bool                   // set by closeSlots; new repoLimiters start closed
+	repoLocksMu  sync.Mutex
+
+	jobCtx     context.Context // passed to every job; cancelled when Stop gives up waiting
+	cancelJobs context.CancelFunc
 }
 
-// NewScheduler creates a scheduler with a controller-runtime client for reading CRDs.
-func NewScheduler(rtClient client.Client) *Scheduler {
+// NewScheduler creates a scheduler with a controller-runtime client for reading
+// CRDs and the recorder it emits Kubernetes Events through.
+func NewScheduler(rtClient client.Client, recorder record.EventRecorder, opts SchedulerOptions) *Scheduler {
+	if opts.ShutdownGracePeriod <= 0 {
+		opts.ShutdownGracePeriod = DefaultShutdownGracePeriod
+	}
+	jobCtx, cancelJobs := context.WithCancel(context.Background())
 	return &Scheduler{
-		cron:     cron.New(cron.WithSeconds()),
-		rtClient: rtClient,
-		managed:  make(map[string]*scheduledDB),
+		cron:         cron.New(cron.WithSeconds()),
+		rtClient:     rtClient,
+		recorder:     recorder,
+		managed:      make(map[string]*scheduledDB),
+		repoChecks:   make(map[string]*scheduledCheck),
+		queue:        newJobQueue(),
+		activeRuns:   make(map[string]bool),
+		noPolicy:     make(map[string]string),
+		opts:         opts,
+		backupSlots:  newLimiter(opts.MaxConcurrentBackups),
+		triageSlots:  newLimiter(opts.MaxConcurrentTriages),
+		repoLocks:    make(map[string]*sync.Mutex),
+		repoLimiters: make(map[string]*limiter),
+		jobCtx:       jobCtx,
+		cancelJobs:   cancelJobs,
 	}
 }
 
-// Start begins the cron scheduler.
-func (s *Scheduler) Start() {
+// Start runs the cron scheduler until ctx is cancelled. It implements
+// manager.Runnable, so the manager only calls it once this replica holds the
+// leader Lease; losing leadership cancels ctx.
+//
+// Jobs run on a context derived from ctx that is not cancelled with it: Stop
+// lets running jobs finish and only cancels them after the grace period.
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	s.jobCtx, s.cancelJobs = context.WithCancel(context.WithoutCancel(ctx))
+	s.mu.Unlock()
+
 	s.cron.Start()
 	common.InfoLog("Cron scheduler started")
+	go s.refreshBackupAges(ctx)
+	<-ctx.Done()
+	s.Stop()
+	return nil
+}
+
+// NeedLeaderElection reports that the scheduler must only run on the leader.
+func (s *Scheduler) NeedLeaderElection() bool {
+	return true
 }
 
-// Stop halts the cron scheduler.
+// Stop halts the cron scheduler, drops queued jobs and waits for running jobs
+// to return. Jobs still running after the grace period have their context
+// cancelled, which makes repairs and restores run their cleanup (unfence,
+// resume the CR, delete helper pods); Stop waits up to engine.CleanupTimeout
+// more for that.
 func (s *Scheduler) Stop() {
-	s.cron.Stop()
-	common.InfoLog("Cron scheduler stopped")
+	// Jobs still waiting for a slot give up rather than start now
+	s.closeSlots()
+
+	done := make(chan struct{})
+	go func() {
+		<-s.cron.Stop().Done()
+		s.queue.stop()
+		close(done)
+	}()
+
+	s.mu.RLock()
+	cancel := s.cancelJobs
+	s.mu.RUnlock()
+	defer cancel()
+
+	select {
+	case <-done:
+		common.InfoLog("Cron scheduler stopped")
+		return
+	case <-time.After(s.opts.ShutdownGracePeriod):
+	}
+
+	common.WarnLog("Jobs still running after %s, cancelling them", s.opts.ShutdownGracePeriod)
+	cancel()
+	select {
+	case <-done:
+		common.InfoLog("Cron scheduler stopped (running jobs cancelled)")
+	case <-time.After(engine.CleanupTimeout):
+		common.ErrorLog("Jobs did not finish cleanup within %s", engine.CleanupTimeout)
+	}
+}
+
+// jobContext returns the context jobs run with. It is cancelled when Stop
+// gives up waiting for running jobs.
+func (s *Scheduler) jobContext() context.Context {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.jobCtx
 }
 
 // Register adds or updates a managed database in the scheduler.
@@ -67,11 +184,13 @@ func (s *Scheduler) Stop() {
 func (s *Scheduler) Register(key string, db *ManagedDB) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	delete(s.noPolicy, key)
 
 	// Check if already registered with same schedules
 	if existing, ok := s.managed[key]; ok {
 		if existing.db.Config.BackupSchedule == db.Config.BackupSchedule &&
 			existing.db.Config.TriageSchedule == db.Config.TriageSchedule &&
+			existing.db.Config.PruneSchedule == db.Config.PruneSchedule &&
 			existing.db.Config.Mode == db.Config.Mode &&
 			reposEqual(existing.db.Config.Repositories, db.Config.Repositories) {
 			// Update config in place (retention, timeouts may have changed)
@@ -88,8 +207,10 @@ func (s *Scheduler) Register(key string, db *ManagedDB) {
 	if db.Config.BackupSchedule != "" && db.Config.Mode != "disabled" {
 		for _, repoName := range db.Config.Repositories {
 			repo := repoName // capture
-			id, err := s.cron.AddFunc(db.Config.BackupSchedule, func() {
-				s.runBackup(db, repo)
+			id, err := s.addSplayed(key, db.Config.BackupSchedule, func() {
+				s.enqueue(key, opBackup, repo, func(ctx context.Context, current *ManagedDB) {
+					s.runBackup(ctx, current, repo)
+				})
 			})
 			if err != nil {
 				common.ErrorLog("Failed to schedule backup for %s repo %s: %v", key, repo, err)
@@ -100,10 +221,31 @@ func (s *Scheduler) Register(key string, db *ManagedDB) {
 		}
 	}
 
+	// Schedule retention (one per repository). Without a prune schedule,
+	// retention runs after each successful backup instead.
+	if db.Config.PruneSchedule != "" && db.Config.Mode != "disabled" {
+		for _, repoName := range db.Config.Repositories {
+			repo := repoName // capture
+			id, err := s.addSplayed(key, db.Config.PruneSchedule, func() {
+				s.enqueue(key, opPrune, repo, func(ctx context.Context, current *ManagedDB) {
+					s.runRetention(ctx, current, repo)
+				})
+			})
+			if err != nil {
+				common.ErrorLog("Failed to schedule retention for %s repo %s: %v", key, repo, err)
+				continue
+			}
+			entry.pruneIDs = append(entry.pruneIDs, id)
+			common.InfoLog("Scheduled retention for %s → repo %s (%s)", key, repo, db.Config.PruneSchedule)
+		}
+	}
+
 	// Schedule triage
 	if db.Config.TriageSchedule != "" && db.Config.Mode != "disabled" {
-		id, err := s.cron.AddFunc(db.Config.TriageSchedule, func() {
-			s.runTriage(db)
+		id, err := s.addSplayed(key, db.Config.TriageSchedule, func() {
+			s.enqueue(key, opTriage, "", func(ctx context.Context, current *ManagedDB) {
+				s.runTriage(ctx, current)
+			})
 		})
 		if err != nil {
 			common.ErrorLog("Failed to schedule triage for %s: %v", key, err)
@@ -121,6 +263,12 @@ func (s *Scheduler) Register(key string, db *ManagedDB) {
 func (s *Scheduler) Deregister(key string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	if entry, ok := s.managed[key]; ok {
+		metrics.DeleteBackupAge(entry.db.Engine, entry.db.ClusterName, entry.db.Namespace)
+		metrics.DeletePolicyConflicts(entry.db.Engine, entry.db.ClusterName, entry.db.Namespace)
+		metrics.DeleteRepairCircuit(entry.db.Engine, entry.db.ClusterName, entry.db.Namespace)
+	}
+	delete(s.noPolicy, key)
 	s.deregisterLocked(key)
 }
 
@@ -132,14 +280,143 @@ func (s *Scheduler) deregisterLocked(key string) {
 	for _, id := range entry.backupIDs {
 		s.cron.Remove(id)
 	}
+	for _, id := range entry.pruneIDs {
+		s.cron.Remove(id)
+	}
 	if entry.triageID != 0 {
 		s.cron.Remove(entry.triageID)
 	}
+	for _, t := range entry.deferred {
+		t.Stop()
+	}
 	delete(s.managed, key)
+	s.queue.remove(key)
 	common.InfoLog("Deregistered %s from scheduler", key)
 	s.updateManagedGauge()
 }
 
+// enqueue queues fn on the database's job queue. Jobs are identified by
+// operation and target (repository), so a backup to repo A and a backup to
+// repo B are distinct, while a second trigger for either while it is still
+// pending is coalesced. The database is resolved again when the job starts so
+// in-place config updates apply and deregistered databases are skipped. fn
+// runs with the scheduler's job context.
+// Returns false if the database is not registered or the job was coalesced.
+func (s *Scheduler) enqueue(key, op, target string, fn func(ctx context.Context, db *ManagedDB)) bool {
+	db, ok := s.lookup(key)
+	if !ok {
+		return false
+	}
+	id := op
+	if target != "" {
+		id += "/" + target
+	}
+	return s.queue.enqueue(key, db, id, op, func() {
+		if current, ok := s.lookup(key); ok {
+			fn(s.jobContext(), current)
+		}
+	})
+}
+
+// lookup returns the current ManagedDB for a key. Cron callbacks resolve the
+// database at fire time so in-place config updates (retention, timeouts) apply.
+func (s *Scheduler) lookup(key string) (*ManagedDB, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.managed[key]
+	if !ok {
+		return nil, false
+	}
+	return entry.db, true
+}
+
+// missingPolicy returns the policy a database was deregistered for, if it
+// was deregistered because that policy no longer exists.
+func (s *Scheduler) missingPolicy(key string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	name, ok := s.noPolicy[key]
+	return name, ok
+}
+
+// managedDBs returns a snapshot of the registered databases.
+func (s *Scheduler) managedDBs() []*ManagedDB {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	dbs := make([]*ManagedDB, 0, len(s.managed))
+	for _, entry := range s.managed {
+		dbs = append(dbs, entry.db)
+	}
+	return dbs
+}
+
+// repoLock returns the mutex guarding exclusive operations on a repository.
+func (s *Scheduler) repoLock(repoName string) *sync.Mutex {
+	s.repoLocksMu.Lock()
+	defer s.repoLocksMu.Unlock()
+	l, ok := s.repoLocks[repoName]
+	if !ok {
+		l = &sync.Mutex{}
+		s.repoLocks[repoName] = l
+	}
+	return l
+}
+
+// swapTriageResult stores the latest triage result, and the TriageResult it
+// came from, for a database and returns the previous result ("" if none is
+// recorded).
+func (s *Scheduler) swapTriageResult(key, result string, triaged *model.TriageResult) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.managed[key]
+	if !ok {
+		return ""
+	}
+	previous := entry.lastTriageResult
+	entry.lastTriageResult = result
+	entry.lastTriage = triaged
+	return previous
+}
+
+// lastTriage returns the full result of the database's last triage since the
+// operator started, or nil.
+func (s *Scheduler) lastTriage(key string) *model.TriageResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.managed[key]
+	if !ok {
+		return nil
+	}
+	return entry.lastTriage
+}
+
+// claimRun marks an on-demand run (BackupRun, RestoreRequest) as executing
+// in this process. Returns false if it already is.
+func (s *Scheduler) claimRun(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.activeRuns[key] {
+		return false
+	}
+	s.activeRuns[key] = true
+	return true
+}
+
+func (s *Scheduler) releaseRun(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.activeRuns, key)
+}
+
+// runActive reports whether an on-demand run is executing in this process.
+// A run whose status says it is in progress but is not active here was
+// interrupted by an operator restart or leader change.
+func (s *Scheduler) runActive(key string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.activeRuns[key]
+}
+
 // ManagedCount returns the number of managed databases.
 func (s *Scheduler) ManagedCount() int {
 	s.mu.RLock()
@@ -147,86 +424,65 @@ func (s *Scheduler) ManagedCount() int {
 	return len(s.managed)
 }
 
-// getRepoCredentials fetches restic connection details from a BackupRepository CR.
-func (s *Scheduler) getRepoCredentials(ctx context.Context, repoName string) (repository, password string, env map[string]string, err error) {
-	repo := &v1alpha1.BackupRepository{}
-	if err := s.rtClient.Get(ctx, types.NamespacedName{Name: repoName}, repo); err != nil {
-		return "", "", nil, fmt.Errorf("BackupRepository %q not found: %w", repoName, err)
+// getRepoCredentials fetches restic connection details for a repository
+// reference (BackupRepository or NamespacedBackupRepository).
+func (s *Scheduler) getRepoCredentials(ctx context.Context, repoRef string) (repository, password string, env map[string]string, err error) {
+	repo, err := getRepository(ctx, s.rtClient, repoRef)
+	if err != nil {
+		return "", "", nil, err
+	}
+	return resolveRepoCredentials(ctx, s.rtClient, repo)
+}
+
+// repoMaxConcurrency returns spec.maxConcurrency for a repository, or 0
+// (use the operator default) if it is unset or the CR cannot be read.
+func (s *Scheduler) repoMaxConcurrency(ctx context.Context, repoRef string) int {
+	repo, err := getRepository(ctx, s.rtClient, repoRef)
+	if err != nil {
+		return 0
 	}
+	return repo.spec.MaxConcurrency
+}
 
+// resolveRepoCredentials reads the password and optional env Secrets referenced
+// by a repository. The password and every env value are registered as
+// secrets for log redaction.
+func resolveRepoCredentials(ctx context.Context, c client.Client, repo *repositoryObject) (repository, password string, env map[string]string, err error) {
 	// Get password from secret
-	ref := repo.Spec.Restic.PasswordSecretRef
+	ref := repo.spec.Restic.PasswordSecretRef
+	ns, err := repo.secretNamespace(ref.Namespace)
+	if err != nil {
+		return "", "", nil, err
+	}
 	secret := &corev1.Secret{}
-	if err := s.rtClient.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, secret); err != nil {
-		return "", "", nil, fmt.Errorf("password Secret %s/%s not found: %w", ref.Namespace, ref.Name, err)
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ns}, secret); err != nil {
+		return "", "", nil, fmt.Errorf("password Secret %s/%s not found: %w", ns, ref.Name, err)
 	}
 	pw, ok := secret.Data[ref.Key]
 	if !ok {
-		return "", "", nil, fmt.Errorf("key %q not found in Secret %s/%s", ref.Key, ref.Namespace, ref.Name)
+		return "", "", nil, fmt.Errorf("key %q not found in Secret %s/%s", ref.Key, ns, ref.Name)
 	}
 
 	// Get env vars from optional secret
 	envMap := make(map[string]string)
-	if repo.Spec.Restic.EnvSecretRef != nil {
-		envRef := repo.Spec.Restic.EnvSecretRef
+	if repo.spec.Restic.EnvSecretRef != nil {
+		envRef := repo.spec.Restic.EnvSecretRef
+		envNs, err := repo.secretNamespace(envRef.Namespace)
+		if err != nil {
+			return "", "", nil, err
+		}
 		envSecret := &corev1.Secret{}
-		if err := s.rtClient.Get(ctx, types.NamespacedName{Name: envRef.Name, Namespace: envRef.Namespace}, envSecret); err != nil {
-			return "", "", nil, fmt.Errorf("env Secret %s/%s not found: %w", envRef.Namespace, envRef.Name, err)
+		if err := c.Get(ctx, types.NamespacedName{Name: envRef.Name, Namespace: envNs}, envSecret); err != nil {
+			return "", "", nil, fmt.Errorf("env Secret %s/%s not found: %w", envNs, envRef.Name, err)
 		}
 		for k, v := range envSecret.Data {
 			envMap[k] = string(v)
+			common.RegisterSecret(string(v))
 		}
 	}
+	common.RegisterSecret(string(pw))
 
-	return repo.Spec.Restic.Repository, string(pw), envMap, nil
-}
-
-// updateStatusAnnotation patches a status annotation on a database CR.
-func (s *Scheduler) updateStatusAnnotation(ctx context.Context, db *ManagedDB, key, value string) {
-	var gvr schema.GroupVersionResource
-	switch db.Engine {
-	case "cnpg":
-		gvr = k8s.CNPGClusterGVR
-	case "galera":
-		gvr = k8s.MariaDBGVR
-	}
-
-	c := k8s.GetClients()
-	patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, key, value))
-	_, err := c.Dynamic.Resource(gvr).Namespace(db.Namespace).Patch(
-		ctx, db.ClusterName, types.MergePatchType, patch, k8s.PatchOptions)
-	if err != nil {
-		common.WarnLog("Failed to update annotation %s on %s/%s: %v", key, db.Namespace, db.ClusterName, err)
-	}
-}
-
-// updateMultipleAnnotations patches multiple status annotations at once.
-func (s *Scheduler) updateMultipleAnnotations(ctx context.Context, db *ManagedDB, kv map[string]string) {
-	var gvr schema.GroupVersionResource
-	switch db.Engine {
-	case "cnpg":
-		gvr = k8s.CNPGClusterGVR
-	case "galera":
-		gvr = k8s.MariaDBGVR
-	}
-
-	annotations := ""
-	first := true
-	for k, v := range kv {
-		if !first {
-			annotations += ","
-		}
-		annotations += fmt.Sprintf("%q:%q", k, v)
-		first = false
-	}
-
-	c := k8s.GetClients()
-	patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{%s}}}`, annotations))
-	_, err := c.Dynamic.Resource(gvr).Namespace(db.Namespace).Patch(
-		ctx, db.ClusterName, types.MergePatchType, patch, k8s.PatchOptions)
-	if err != nil {
-		common.WarnLog("Failed to update annotations on %s/%s: %v", db.Namespace, db.ClusterName, err)
-	}
+	return repo.spec.Restic.Repository, string(pw), envMap, nil
 }
 
 func reposEqual(a, b []string) bool {
@@ -252,8 +508,3 @@ func (s *Scheduler) updateManagedGauge() {
 		metrics.RecordManagedDatabases(eng, counts[eng])
 	}
 }
-
-// nowRFC3339 returns the current time in RFC3339 format.
-func nowRFC3339() string {
-	return time.Now().UTC().Format(time.RFC3339)
-}
@@ -0,0 +1,238 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+	"github.com/PrPlanIT/HASteward/src/common"
+	"github.com/PrPlanIT/HASteward/src/events"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Result values recorded in ManagedDatabase status.
+const (
+	resultSucceeded = "succeeded"
+	resultFailed    = "failed"
+	resultRefused   = "refused"
+)
+
+// updateStatus applies mutate to the database's ManagedDatabase status and
+// refreshes NextRuns, retrying on conflict. A missing ManagedDatabase is not
+// an error: the database reconciler creates it on the next reconcile.
+func (s *Scheduler) updateStatus(ctx context.Context, db *ManagedDB, mutate func(*v1alpha1.ManagedDatabaseStatus)) {
+	name := types.NamespacedName{Namespace: db.Namespace, Name: v1alpha1.ManagedDatabaseName(db.Engine, db.ClusterName)}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		md := &v1alpha1.ManagedDatabase{}
+		if err := s.rtClient.Get(ctx, name, md); err != nil {
+			return err
+		}
+		mutate(&md.Status)
+		md.Status.NextRuns = s.nextRuns(db.key())
+		return s.rtClient.Status().Update(ctx, md)
+	})
+	if errors.IsNotFound(err) {
+		common.DebugLog("ManagedDatabase %s not found, status not recorded", name)
+		return
+	}
+	if err != nil {
+		common.WarnLog("Failed to update ManagedDatabase %s status: %v", name, err)
+	}
+}
+
+// nextRuns returns the earliest upcoming fire time of each scheduled operation
+// for a database, as computed by the cron scheduler (splay included).
+func (s *Scheduler) nextRuns(key string) v1alpha1.ScheduledRuns {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var runs v1alpha1.ScheduledRuns
+	entry, ok := s.managed[key]
+	if !ok {
+		return runs
+	}
+	runs.Backup = s.earliest(entry.backupIDs)
+	runs.Prune = s.earliest(entry.pruneIDs)
+	if entry.triageID != 0 {
+		runs.Triage = s.earliest([]cron.EntryID{entry.triageID})
+	}
+	return runs
+}
+
+// earliest returns the soonest Next time among cron entries, or nil.
+// Entries the cron has not started yet are resolved from their schedule.
+func (s *Scheduler) earliest(ids []cron.EntryID) *metav1.Time {
+	var next time.Time
+	now := time.Now()
+	for _, id := range ids {
+		e := s.cron.Entry(id)
+		if !e.Valid() {
+			continue
+		}
+		t := e.Next
+		if t.IsZero() {
+			t = e.Schedule.Next(now)
+		}
+		if next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+	if next.IsZero() {
+		return nil
+	}
+	mt := metav1.NewTime(next)
+	return &mt
+}
+
+// repositoryBackup returns the backup status entry for a repository,
+// appending a new one if needed.
+func repositoryBackup(st *v1alpha1.ManagedDatabaseStatus, repoName string) *v1alpha1.RepositoryBackupStatus {
+	for i := range st.Backups {
+		if st.Backups[i].Repository == repoName {
+			return &st.Backups[i]
+		}
+	}
+	st.Backups = append(st.Backups, v1alpha1.RepositoryBackupStatus{Repository: repoName})
+	return &st.Backups[len(st.Backups)-1]
+}
+
+// pruneRepositories drops backup entries for repositories no longer targeted.
+func pruneRepositories(st *v1alpha1.ManagedDatabaseStatus, repos []string) {
+	keep := st.Backups[:0]
+	for _, b := range st.Backups {
+		for _, r := range repos {
+			if b.Repository == r {
+				keep = append(keep, b)
+				break
+			}
+		}
+	}
+	st.Backups = keep
+}
+
+// ensureManagedDatabase creates the ManagedDatabase for a database CR if it
+// does not exist and records the effective config. The database CR is the
+// owner, so deleting it garbage-collects the ManagedDatabase.
+func (s *Scheduler) ensureManagedDatabase(ctx context.Context, obj *unstructured.Unstructured, db *ManagedDB) error {
+	name := v1alpha1.ManagedDatabaseName(db.Engine, db.ClusterName)
+	md := &v1alpha1.ManagedDatabase{}
+	err := s.rtClient.Get(ctx, types.NamespacedName{Namespace: db.Namespace, Name: name}, md)
+	if errors.IsNotFound(err) {
+		md = &v1alpha1.ManagedDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: db.Namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/managed-by": "hasteward",
+				},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: obj.GetAPIVersion(),
+					Kind:       obj.GetKind(),
+					Name:       obj.GetName(),
+					UID:        obj.GetUID(),
+				}},
+			},
+			Spec: v1alpha1.ManagedDatabaseSpec{
+				Engine:      db.Engine,
+				ClusterName: db.ClusterName,
+			},
+		}
+		if err := s.rtClient.Create(ctx, md); err != nil {
+			return fmt.Errorf("failed to create ManagedDatabase %s/%s: %w", db.Namespace, name, err)
+		}
+		// Write the first status from the created object; the cache may not
+		// have observed it yet.
+		md.Status.EffectiveConfig = *db.Config
+		md.Status.NextRuns = s.nextRuns(db.key())
+		if err := s.rtClient.Status().Update(ctx, md); err != nil {
+			return fmt.Errorf("failed to update ManagedDatabase %s/%s status: %w", db.Namespace, name, err)
+		}
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// Database CRs reconcile on every change to their own status; only
+	// write when the effective config moved.
+	if equality.Semantic.DeepEqual(md.Status.EffectiveConfig, *db.Config) {
+		return nil
+	}
+	s.updateStatus(ctx, db, func(st *v1alpha1.ManagedDatabaseStatus) {
+		st.EffectiveConfig = *db.Config
+		pruneRepositories(st, db.Config.Repositories)
+	})
+	return nil
+}
+
+// deleteManagedDatabase removes the ManagedDatabase for a database that
+// opted out or was excluded.
+func (s *Scheduler) deleteManagedDatabase(ctx context.Context, engine, namespace, clusterName string) {
+	md := &v1alpha1.ManagedDatabase{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      v1alpha1.ManagedDatabaseName(engine, clusterName),
+			Namespace: namespace,
+		},
+	}
+	if err := s.rtClient.Delete(ctx, md); client.IgnoreNotFound(err) != nil {
+		common.WarnLog("Failed to delete ManagedDatabase %s/%s: %v", namespace, md.Name, err)
+	}
+}
+
+// deregisterMissingPolicy stops scheduling a database whose policy no longer
+// exists. Unlike an opt-out, the ManagedDatabase is kept (with NextRuns
+// cleared) and a Warning event is recorded, so the stopped backups are
+// visible rather than silently gone. On-demand runs for the database fail
+// until the policy returns.
+func (s *Scheduler) deregisterMissingPolicy(ctx context.Context, key, policyName string) {
+	db, ok := s.lookup(key)
+	s.Deregister(key)
+	s.mu.Lock()
+	s.noPolicy[key] = policyName
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+	common.WarnLog("Policy %q of %s no longer exists; backups and triage stopped", policyName, key)
+	s.recordEvent(ctx, db, corev1.EventTypeWarning,
+		events.ReasonPolicyNotFound, nil, "BackupPolicy %q not found; scheduled backups and triage stopped", policyName)
+	s.updateStatus(ctx, db, func(*v1alpha1.ManagedDatabaseStatus) {})
+}
+
+// registerWait is how long an on-demand run waits for the database
+// reconciler to register a database that already has a ManagedDatabase
+// (typically right after operator startup).
+const registerWait = 15 * time.Second
+
+// resolveManagedDB returns the registered database for an on-demand run.
+// A non-empty failure means the run can never proceed: the database is not
+// opted in (no ManagedDatabase), or its BackupPolicy no longer exists. A nil
+// db without a failure means it is not registered yet; retry after
+// registerWait.
+func (s *Scheduler) resolveManagedDB(ctx context.Context, engine, namespace, clusterName string) (db *ManagedDB, failure string, err error) {
+	key := engine + "/" + namespace + "/" + clusterName
+	if db, ok := s.lookup(key); ok {
+		return db, "", nil
+	}
+	if policyName, ok := s.missingPolicy(key); ok {
+		return nil, fmt.Sprintf("BackupPolicy %q of %s %s/%s not found", policyName, engine, namespace, clusterName), nil
+	}
+	md := &v1alpha1.ManagedDatabase{}
+	err = s.rtClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: v1alpha1.ManagedDatabaseName(engine, clusterName)}, md)
+	if errors.IsNotFound(err) {
+		return nil, fmt.Sprintf("%s %s/%s is not managed by hasteward", engine, namespace, clusterName), nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return nil, "", nil
+}
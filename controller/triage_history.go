@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"strconv"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+	"github.com/PrPlanIT/HASteward/src/engine/triage"
+	"github.com/PrPlanIT/HASteward/src/output/model"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// triageSnapshot condenses a triage result into a triage history entry.
+// CNPG lag is computed against the most advanced checkpoint LSN of the
+// instances; Galera lag is the triage's own seqno lag.
+func triageSnapshot(engine string, result *model.TriageResult, triageResult string, now metav1.Time) v1alpha1.TriageSnapshot {
+	snap := v1alpha1.TriageSnapshot{
+		Time:         now,
+		Result:       triageResult,
+		ReadyCount:   result.ReadyCount,
+		TotalCount:   result.TotalCount,
+		ClusterPhase: result.ClusterPhase,
+	}
+
+	var maxLSN int64
+	if engine == "cnpg" {
+		for _, a := range result.Assessments {
+			maxLSN = max(maxLSN, triage.ParseLSN(a.LSN))
+		}
+	}
+
+	for _, a := range result.Assessments {
+		inst := v1alpha1.InstanceSnapshot{Pod: a.Pod, Ready: a.IsReady, DiskPct: a.DiskPct}
+		switch engine {
+		case "cnpg":
+			inst.Primary = a.IsPrimary
+			inst.Position = a.LSN
+			if lsn := triage.ParseLSN(a.LSN); lsn > 0 {
+				inst.Lag = maxLSN - lsn
+			}
+		case "galera":
+			inst.Primary = a.IsInPrimary
+			seqno := a.EffectiveSeqno
+			if seqno == 0 {
+				seqno = a.Seqno
+			}
+			inst.Position = strconv.FormatInt(seqno, 10)
+			inst.Lag = a.SeqnoLag
+		}
+		snap.Instances = append(snap.Instances, inst)
+	}
+	return snap
+}
+
+// appendTriageHistory prepends a snapshot to the history, keeping at most
+// MaxTriageHistory entries.
+func appendTriageHistory(history []v1alpha1.TriageSnapshot, snap v1alpha1.TriageSnapshot) []v1alpha1.TriageSnapshot {
+	history = append([]v1alpha1.TriageSnapshot{snap}, history...)
+	if len(history) > v1alpha1.MaxTriageHistory {
+		history = history[:v1alpha1.MaxTriageHistory]
+	}
+	return history
+}
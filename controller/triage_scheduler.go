@@ -2,7 +2,10 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
 	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
 	"github.com/PrPlanIT/HASteward/src/common"
@@ -10,15 +13,26 @@ import (
 	"github.com/PrPlanIT/HASteward/src/engine/provider"
 	"github.com/PrPlanIT/HASteward/src/engine/repair"
 	"github.com/PrPlanIT/HASteward/src/engine/triage"
+	"github.com/PrPlanIT/HASteward/src/events"
 	"github.com/PrPlanIT/HASteward/src/metrics"
+	"github.com/PrPlanIT/HASteward/src/output/model"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // runTriage is called by the cron scheduler to health-check a database.
-// If mode=repair and unhealthy instances are found, it triggers auto-repair.
-func (s *Scheduler) runTriage(db *ManagedDB) {
-	ctx := context.Background()
+// If mode=repair and unhealthy instances are found, it triggers auto-repair;
+// split-brain and refused repairs become RepairProposals.
+func (s *Scheduler) runTriage(ctx context.Context, db *ManagedDB) {
 	log := slog.With("engine", db.Engine, "cluster", db.ClusterName, "namespace", db.Namespace)
 
+	if !s.triageSlots.acquire() {
+		log.Info("Triage not started", "reason", errShuttingDown)
+		return
+	}
+	defer s.triageSlots.release()
+
 	log.Info("Starting scheduled triage")
 
 	// Build engine config (triage doesn't need restic credentials)
@@ -69,6 +83,11 @@ func (s *Scheduler) runTriage(db *ManagedDB) {
 		"total", result.TotalCount,
 		"result", triageResult)
 
+	rpoViolations := s.rpoViolations(ctx, db)
+	for _, v := range rpoViolations {
+		log.Warn("RPO violated", "detail", v)
+	}
+
 	// Record triage metrics (use underscore for split-brain label)
 	metricsResult := triageResult
 	if metricsResult == "split-brain" {
@@ -76,34 +95,111 @@ func (s *Scheduler) runTriage(db *ManagedDB) {
 	}
 	metrics.RecordTriageResult(db.Engine, db.ClusterName, db.Namespace, result, metricsResult)
 
-	// Update status annotations
-	s.updateMultipleAnnotations(ctx, db, map[string]string{
-		v1alpha1.AnnotationLastTriage:       nowRFC3339(),
-		v1alpha1.AnnotationLastTriageResult: triageResult,
+	now := metav1.Now()
+	snapshot := triageSnapshot(db.Engine, result, triageResult, now)
+	s.updateStatus(ctx, db, func(st *v1alpha1.ManagedDatabaseStatus) {
+		st.TriageHistory = appendTriageHistory(st.TriageHistory, snapshot)
+		st.Triage = &v1alpha1.TriageStatus{
+			Time:              now,
+			Result:            triageResult,
+			ReadyCount:        result.ReadyCount,
+			TotalCount:        result.TotalCount,
+			ClusterPhase:      result.ClusterPhase,
+			AuthorityStatus:   result.AuthorityStatus,
+			RecommendedDonor:  result.RecommendedDonor,
+			SplitBrainDetails: result.DataComparison.SplitBrainDetails,
+			RPOViolations:     rpoViolations,
+		}
 	})
 
-	// Auto-repair if mode=repair and there are unhealthy instances
-	if db.Config.Mode == "repair" && triageResult == "unhealthy" {
-		s.runAutoRepair(ctx, db, log)
+	// Only the transition into split-brain is an event; repeating it on
+	// every triage run would bury everything else in `kubectl describe`.
+	if previous := s.swapTriageResult(db.key(), triageResult, result); triageResult == "split-brain" && previous != "split-brain" {
+		s.recordEvent(ctx, db, corev1.EventTypeWarning,
+			events.ReasonSplitBrainDetected, result, "Split-brain detected: %s",
+			strings.Join(result.DataComparison.SplitBrainDetails, "; "))
+	}
+
+	if db.Config.Mode != "repair" {
+		return
+	}
+	switch triageResult {
+	case "healthy":
+		// Nothing left to approve
+		s.expireRepairProposals(ctx, db, log)
+	case "unhealthy":
+		s.runAutoRepair(ctx, db, log, result)
+	case "split-brain":
+		// Auto-repair never overrides split-brain; ask a human instead
+		s.proposeRepair(ctx, db, log, result, v1alpha1.RepairTriggerSplitBrain, result.DataComparison.SplitBrainDetails, nil)
 	}
 }
 
-// runAutoRepair attempts to repair unhealthy instances after a triage detects problems.
-func (s *Scheduler) runAutoRepair(ctx context.Context, db *ManagedDB, log *slog.Logger) {
+// runAutoRepair attempts to repair unhealthy instances after a triage detects
+// problems, within the database's repair limits. A repair refused by a safety
+// gate becomes a RepairProposal.
+func (s *Scheduler) runAutoRepair(ctx context.Context, db *ManagedDB, log *slog.Logger, triaged *model.TriageResult) {
+	if s.deferRepairToMaintenance(db, log) {
+		return
+	}
+	if !s.repairAllowed(ctx, db, log) {
+		return
+	}
 	log.Info("Auto-repair triggered (mode=repair)")
 
+	started := time.Now()
+	_, repairer, err := s.executeRepair(ctx, db, log, repairRun{label: "Auto-repair"})
+	if err != nil && repair.IsSafetyGate(err) {
+		var donor *repair.DonorSelection
+		if dr, ok := repairer.(repair.DonorReporter); ok {
+			donor = dr.Donor()
+		}
+		s.proposeRepair(ctx, db, log, triaged, v1alpha1.RepairTriggerSafetyGate, []string{err.Error()}, donor)
+		return
+	}
+	// Only repairs that actually ran count against the limits; setup
+	// failures (credentials, engine) never touched the database, and a
+	// repair interrupted by shutdown says nothing about it
+	if repairer != nil && ctx.Err() == nil {
+		s.recordRepairOutcome(ctx, db, log, started, err)
+	}
+}
+
+// repairRun describes one repair: the scheduled auto-repair, or one target of
+// an approved RepairProposal.
+type repairRun struct {
+	label    string // prefixes event messages, e.g. "Auto-repair"
+	instance *int
+	donor    *int
+	force    bool
+}
+
+// executeRepair runs repair.Run for a database with escrow to its first
+// repository, recording metrics, events and ManagedDatabase status. The
+// repairer is returned (when one was built) so callers can inspect it after a
+// refusal.
+func (s *Scheduler) executeRepair(ctx context.Context, db *ManagedDB, log *slog.Logger, run repairRun) (*model.RepairResult, repair.Repairer, error) {
 	// Repair needs restic credentials for escrow backup.
 	// Use the first configured repository.
 	if len(db.Config.Repositories) == 0 {
-		log.Warn("No repositories configured, skipping auto-repair (escrow requires a repository)")
-		return
+		log.Warn("No repositories configured, skipping repair (escrow requires a repository)")
+		return nil, nil, fmt.Errorf("no repositories configured (escrow requires a repository)")
 	}
 
 	repoName := db.Config.Repositories[0]
-	repository, password, _, err := s.getRepoCredentials(ctx, repoName)
+
+	// Escrow writes to the repository like a backup does
+	slots := s.repoSlots(repoName, s.repoMaxConcurrency(ctx, repoName))
+	if !slots.acquire() {
+		log.Info("Repair not started", "label", run.label, "reason", errShuttingDown)
+		return nil, nil, errShuttingDown
+	}
+	defer slots.release()
+
+	repository, password, envVars, err := s.getRepoCredentials(ctx, repoName)
 	if err != nil {
 		log.Error("Failed to get repository credentials for repair escrow", "repository", repoName, "error", err)
-		return
+		return nil, nil, fmt.Errorf("failed to get repository credentials: %w", err)
 	}
 	common.RegisterSecret(password)
 
@@ -114,7 +210,11 @@ func (s *Scheduler) runAutoRepair(ctx context.Context, db *ManagedDB, log *slog.
 		Mode:           "repair",
 		BackupsPath:    repository,
 		ResticPassword: password,
+		ResticEnv:      envVars,
 		BackupMethod:   "dump",
+		InstanceNumber: run.instance,
+		DonorInstance:  run.donor,
+		Force:          run.force,
 		HealTimeout:    db.Config.HealTimeout,
 		DeleteTimeout:  db.Config.DeleteTimeout,
 	}
@@ -122,32 +222,63 @@ func (s *Scheduler) runAutoRepair(ctx context.Context, db *ManagedDB, log *slog.
 	prov, err := provider.GetProvider(cfg.Engine)
 	if err != nil {
 		log.Error("Engine not found for repair", "error", err)
-		return
+		return nil, nil, fmt.Errorf("engine not found: %w", err)
 	}
 	if err := prov.Validate(ctx, cfg); err != nil {
 		log.Error("Engine validation failed for repair", "error", err)
-		return
+		return nil, nil, fmt.Errorf("engine validation failed: %w", err)
 	}
 
 	repairer, err := repair.Get(prov)
 	if err != nil {
 		log.Error("Repairer not found", "error", err)
-		return
+		return nil, nil, err
 	}
 
+	s.recordEvent(ctx, db, corev1.EventTypeNormal,
+		events.ReasonRepairStarted, nil, "%s started (escrow to repository %s)", run.label, repoName)
+
 	result, err := repair.Run(ctx, repairer, engine.NopSink{})
 	if err != nil {
-		log.Error("Auto-repair failed", "error", err)
+		// An interrupted repair is still recorded, so use a context that
+		// survives the cancelled job
+		ctx, cancel := engine.CleanupContext(ctx)
+		defer cancel()
+		log.Error("Repair failed", "label", run.label, "error", err)
 		metrics.RecordRepairFailure(db.Engine, db.ClusterName, db.Namespace)
-		s.updateStatusAnnotation(ctx, db, v1alpha1.AnnotationLastRepair, nowRFC3339())
-		return
+		reason := events.ReasonRepairFailed
+		if repair.IsSafetyGate(err) {
+			reason = events.ReasonRepairRefused
+		}
+		s.recordEvent(ctx, db, corev1.EventTypeWarning,
+			reason, nil, "%s: %v", run.label, err)
+		repairResult := resultFailed
+		if reason == events.ReasonRepairRefused {
+			repairResult = resultRefused
+		}
+		s.updateStatus(ctx, db, func(st *v1alpha1.ManagedDatabaseStatus) {
+			st.Repair = &v1alpha1.RepairStatus{Time: metav1.Now(), Result: repairResult, Error: err.Error()}
+		})
+		return nil, repairer, err
 	}
 
-	log.Info("Auto-repair completed",
+	log.Info("Repair completed",
+		"label", run.label,
 		"healed", len(result.HealedInstances),
 		"skipped", len(result.SkippedInstances),
 		"duration", result.Duration.String())
 
 	metrics.RecordRepairSuccess(db.Engine, db.ClusterName, db.Namespace)
-	s.updateStatusAnnotation(ctx, db, v1alpha1.AnnotationLastRepair, nowRFC3339())
+	s.recordEvent(ctx, db, corev1.EventTypeNormal,
+		events.ReasonRepairSucceeded, result, "%s healed %d instance(s) in %s: %s",
+		run.label, len(result.HealedInstances), result.Duration.Truncate(time.Second), strings.Join(result.HealedInstances, ", "))
+	s.updateStatus(ctx, db, func(st *v1alpha1.ManagedDatabaseStatus) {
+		st.Repair = &v1alpha1.RepairStatus{
+			Time:            metav1.Now(),
+			Result:          resultSucceeded,
+			HealedInstances: result.HealedInstances,
+			Duration:        result.Duration.Truncate(time.Second).String(),
+		}
+	})
+	return result, repairer, nil
 }
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+	"github.com/PrPlanIT/HASteward/src/common"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// policyRequests maps a BackupPolicy or NamespacedBackupPolicy event to the
+// databases of this engine it may affect: those annotated with its name, those
+// currently bound to it, and, when it has a selector, every database without
+// a policy annotation. Updates are mapped for both the old and new object, so
+// databases a changed selector no longer matches are re-reconciled too.
+func (r *DatabaseReconciler) policyRequests(ctx context.Context, obj client.Object) []reconcile.Request {
+	var spec *v1alpha1.BackupPolicySpec
+	switch p := obj.(type) {
+	case *v1alpha1.BackupPolicy:
+		spec = &p.Spec
+	case *v1alpha1.NamespacedBackupPolicy:
+		spec = &p.Spec
+	default:
+		return nil
+	}
+	namespace := obj.GetNamespace()
+	ref := (&boundPolicy{namespace: namespace, name: obj.GetName()}).ref()
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(r.gvk.GroupVersion().WithKind(r.gvk.Kind + "List"))
+	if err := r.client.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		common.WarnLog("Failed to list %s databases for policy %s: %v", r.engine, ref, err)
+		return nil
+	}
+
+	var reqs []reconcile.Request
+	for i := range list.Items {
+		db := &list.Items[i]
+		annotated := db.GetAnnotations()[v1alpha1.AnnotationPolicy]
+		affected := annotated == obj.GetName() || (annotated == "" && spec.Selector != nil)
+		if !affected {
+			if managed, ok := r.scheduler.lookup(r.engine + "/" + db.GetNamespace() + "/" + db.GetName()); ok {
+				affected = managed.Config.PolicyName == ref
+			}
+		}
+		if affected {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: db.GetNamespace(), Name: db.GetName()}})
+		}
+	}
+	return reqs
+}
+
+// repositoryRequests maps a BackupRepository or NamespacedBackupRepository
+// event to the registered databases of this engine whose repositories may
+// resolve differently: those already targeting it by name or reference, those
+// missing a repository of its name, and those under a NamespacedBackupPolicy,
+// whose repository list is filtered by allowedNamespaces. Namespaced
+// repositories only ever resolve under a NamespacedBackupPolicy.
+func (r *DatabaseReconciler) repositoryRequests(_ context.Context, obj client.Object) []reconcile.Request {
+	namespace := obj.GetNamespace()
+	ref := repositoryRef(namespace, obj.GetName())
+
+	var reqs []reconcile.Request
+	for _, db := range r.scheduler.managedDBs() {
+		if db.Engine != r.engine || (namespace != "" && db.Namespace != namespace) {
+			continue
+		}
+		namespacedPolicy := strings.Contains(db.Config.PolicyName, "/")
+		if namespace != "" && !namespacedPolicy {
+			continue
+		}
+		if namespacedPolicy ||
+			slices.Contains(db.Config.Repositories, ref) ||
+			slices.Contains(db.Config.Repositories, obj.GetName()) ||
+			slices.Contains(db.MissingRepositories, obj.GetName()) {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: db.Namespace, Name: db.ClusterName}})
+		}
+	}
+	return reqs
+}
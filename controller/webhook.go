@@ -0,0 +1,274 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// WebhookPort is where the validating admission webhook listens.
+const WebhookPort = 9443
+
+// Paths served by the webhook. They must match the
+// ValidatingWebhookConfiguration and MutatingWebhookConfiguration in
+// deploy/webhook.
+const (
+	validateResourcesPath = "/validate-hasteward"
+	validateDatabasePath  = "/validate-database"
+	mutateApprovalPath    = "/mutate-approval"
+)
+
+// approvalWebhookConfig is the MutatingWebhookConfiguration that routes
+// approval-gated resources to approvalStamper.
+const approvalWebhookConfig = "hasteward"
+
+// setupWebhooks registers the validating admission handlers on the manager's
+// webhook server. Unlike controllers they run on every replica.
+func setupWebhooks(mgr ctrl.Manager) {
+	srv := mgr.GetWebhookServer()
+	srv.Register(validateResourcesPath, &admission.Webhook{
+		Handler: &resourceValidator{decoder: admission.NewDecoder(mgr.GetScheme())},
+	})
+	srv.Register(validateDatabasePath, &admission.Webhook{Handler: &databaseValidator{}})
+	srv.Register(mutateApprovalPath, &admission.Webhook{Handler: &approvalStamper{}})
+}
+
+// resourceValidator rejects invalid BackupPolicy, BackupRepository (and their
+// namespaced variants) and NotificationChannel resources.
+type resourceValidator struct {
+	decoder admission.Decoder
+}
+
+func (v *resourceValidator) Handle(_ context.Context, req admission.Request) admission.Response {
+	var errs field.ErrorList
+	var warnings []string
+	spec := field.NewPath("spec")
+
+	switch req.Kind.Kind {
+	case "BackupPolicy":
+		obj := &v1alpha1.BackupPolicy{}
+		if err := v.decoder.Decode(req, obj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		errs = v1alpha1.ValidateBackupPolicySpec(&obj.Spec, spec)
+	case "NamespacedBackupPolicy":
+		obj := &v1alpha1.NamespacedBackupPolicy{}
+		if err := v.decoder.Decode(req, obj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		errs = v1alpha1.ValidateBackupPolicySpec(&obj.Spec, spec)
+	case "BackupRepository":
+		obj := &v1alpha1.BackupRepository{}
+		if err := v.decoder.Decode(req, obj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		errs, warnings = v1alpha1.ValidateBackupRepositorySpec(&obj.Spec, "", spec)
+	case "NamespacedBackupRepository":
+		obj := &v1alpha1.NamespacedBackupRepository{}
+		if err := v.decoder.Decode(req, obj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		errs, warnings = v1alpha1.ValidateBackupRepositorySpec(&obj.Spec, req.Namespace, spec)
+	case "NotificationChannel":
+		obj := &v1alpha1.NotificationChannel{}
+		if err := v.decoder.Decode(req, obj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		errs = v1alpha1.ValidateNotificationChannelSpec(&obj.Spec, spec)
+	default:
+		return admission.Allowed("")
+	}
+	return validationResponse(errs, warnings)
+}
+
+// databaseValidator rejects invalid hasteward annotations on CNPG Clusters
+// and MariaDBs. Updates that leave the hasteward annotations unchanged are
+// always allowed, so a database that predates the webhook is never blocked.
+type databaseValidator struct{}
+
+func (v *databaseValidator) Handle(_ context.Context, req admission.Request) admission.Response {
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(req.Object.Raw); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	annotations := hastewardAnnotations(obj.GetAnnotations())
+	if req.Operation == admissionv1.Update && len(req.OldObject.Raw) > 0 {
+		old := &unstructured.Unstructured{}
+		if err := old.UnmarshalJSON(req.OldObject.Raw); err == nil &&
+			maps.Equal(annotations, hastewardAnnotations(old.GetAnnotations())) {
+			return admission.Allowed("")
+		}
+	}
+	errs, warnings := v1alpha1.ValidateAnnotations(annotations)
+	return validationResponse(errs, warnings)
+}
+
+// approvalStamper records who requested and who approved a RestoreRequest or
+// RepairProposal from the authenticated users of the admission requests, so approval does
+// not depend on field managers or on what the client wrote:
+//
+//   - create: requested-by is set to the creating user; approved-by is denied.
+//   - update: requested-by keeps its stored value. When approved-by is added
+//     or changed it is replaced by the updating user, who must not be the
+//     requester.
+//
+// On RepairProposals approved-donor likewise pins the donor-override value
+// approved with approved-by, so a donor changed afterwards was not reviewed.
+type approvalStamper struct{}
+
+func (a *approvalStamper) Handle(_ context.Context, req admission.Request) admission.Response {
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(req.Object.Raw); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	user := req.UserInfo.Username
+
+	switch req.Operation {
+	case admissionv1.Create:
+		if annotations[v1alpha1.AnnotationApprovedBy] != "" {
+			return admission.Denied(v1alpha1.AnnotationApprovedBy + " cannot be set at creation; it must be added by an approver other than the requester")
+		}
+		annotations[v1alpha1.AnnotationRequestedBy] = user
+		delete(annotations, v1alpha1.AnnotationApprovedDonor)
+	case admissionv1.Update:
+		old := &unstructured.Unstructured{}
+		if err := old.UnmarshalJSON(req.OldObject.Raw); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		oldAnnotations := old.GetAnnotations()
+		requester := oldAnnotations[v1alpha1.AnnotationRequestedBy]
+		if requester != "" {
+			annotations[v1alpha1.AnnotationRequestedBy] = requester
+		} else {
+			delete(annotations, v1alpha1.AnnotationRequestedBy)
+		}
+
+		approvedDonor := oldAnnotations[v1alpha1.AnnotationApprovedDonor]
+		approver := annotations[v1alpha1.AnnotationApprovedBy]
+		switch {
+		case approver == "":
+			approvedDonor = ""
+		case approver != oldAnnotations[v1alpha1.AnnotationApprovedBy]:
+			switch {
+			case requester == "":
+				return admission.Denied(obj.GetName() + " has no recorded requester (created before the webhook); recreate it to approve it")
+			case user == requester:
+				return admission.Denied(user + " requested " + obj.GetName() + " and cannot approve it")
+			}
+			annotations[v1alpha1.AnnotationApprovedBy] = user
+			if req.Kind.Kind == "RepairProposal" {
+				approvedDonor = annotations[v1alpha1.AnnotationDonorOverride]
+			}
+		}
+		if approvedDonor != "" {
+			annotations[v1alpha1.AnnotationApprovedDonor] = approvedDonor
+		} else {
+			delete(annotations, v1alpha1.AnnotationApprovedDonor)
+		}
+	default:
+		return admission.Allowed("")
+	}
+
+	obj.SetAnnotations(annotations)
+	patched, err := obj.MarshalJSON()
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, patched)
+}
+
+// approvalWebhookInstalled checks that the approval MutatingWebhookConfiguration
+// sends every create and update of RestoreRequests and RepairProposals to
+// approvalStamper and fails closed. Serving the handler is not enough: if
+// the configuration is missing or scoped narrower, clients write
+// requested-by and approved-by themselves.
+func approvalWebhookInstalled(ctx context.Context, c client.Reader) error {
+	cfg := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	if err := c.Get(ctx, types.NamespacedName{Name: approvalWebhookConfig}, cfg); err != nil {
+		return fmt.Errorf("failed to get MutatingWebhookConfiguration %s: %w", approvalWebhookConfig, err)
+	}
+	for _, resource := range []string{"restorerequests", "repairproposals"} {
+		if !slices.ContainsFunc(cfg.Webhooks, func(w admissionregistrationv1.MutatingWebhook) bool {
+			return stampsResource(w, resource)
+		}) {
+			return fmt.Errorf("MutatingWebhookConfiguration %s does not send every create and update of %s to %s with failurePolicy Fail",
+				approvalWebhookConfig, resource, mutateApprovalPath)
+		}
+	}
+	return nil
+}
+
+// stampsResource reports whether w calls approvalStamper, unconditionally and
+// failing closed, for every create and update of resource.
+func stampsResource(w admissionregistrationv1.MutatingWebhook, resource string) bool {
+	svc := w.ClientConfig.Service
+	if svc == nil || svc.Path == nil || *svc.Path != mutateApprovalPath {
+		return false
+	}
+	if w.FailurePolicy != nil && *w.FailurePolicy != admissionregistrationv1.Fail {
+		return false
+	}
+	if !emptySelector(w.NamespaceSelector) || !emptySelector(w.ObjectSelector) || len(w.MatchConditions) > 0 {
+		return false
+	}
+	for _, rule := range w.Rules {
+		if covers(rule.APIGroups, v1alpha1.GroupVersion.Group) &&
+			covers(rule.APIVersions, v1alpha1.GroupVersion.Version) &&
+			covers(rule.Resources, resource) &&
+			covers(rule.Operations, admissionregistrationv1.Create) &&
+			covers(rule.Operations, admissionregistrationv1.Update) &&
+			(rule.Scope == nil || *rule.Scope == admissionregistrationv1.AllScopes || *rule.Scope == admissionregistrationv1.NamespacedScope) {
+			return true
+		}
+	}
+	return false
+}
+
+// covers reports whether a webhook rule list names v or the wildcard.
+func covers[T ~string](list []T, v T) bool {
+	return slices.Contains(list, v) || slices.Contains(list, "*")
+}
+
+// emptySelector reports whether a webhook selector matches everything.
+func emptySelector(s *metav1.LabelSelector) bool {
+	return s == nil || (len(s.MatchLabels) == 0 && len(s.MatchExpressions) == 0)
+}
+
+// hastewardAnnotations returns the clinic.hasteward.prplanit.com/ annotations.
+func hastewardAnnotations(annotations map[string]string) map[string]string {
+	out := make(map[string]string)
+	for k, v := range annotations {
+		if strings.HasPrefix(k, v1alpha1.AnnotationPrefix) {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// validationResponse denies the request with every field error, or allows
+// it, attaching warnings either way.
+func validationResponse(errs field.ErrorList, warnings []string) admission.Response {
+	if len(errs) > 0 {
+		return admission.Denied(errs.ToAggregate().Error()).WithWarnings(warnings...)
+	}
+	return admission.Allowed("").WithWarnings(warnings...)
+}
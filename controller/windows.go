@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+	"github.com/PrPlanIT/HASteward/src/metrics"
+)
+
+// Window labels of hasteward_scheduler_deferred_total.
+const (
+	windowBlackout    = "blackout"
+	windowMaintenance = "maintenance"
+)
+
+// windowSlack is added to deferred start times so a job never lands on the
+// exact instant its window boundary is evaluated.
+const windowSlack = time.Second
+
+// deferBackupForBlackout defers a scheduled backup that fires inside one of
+// the database's blackout windows to the moment the window closes, and
+// reports whether it did.
+func (s *Scheduler) deferBackupForBlackout(db *ManagedDB, repoName string) bool {
+	end, active := v1alpha1.ActiveWindow(db.Config.BlackoutWindows, time.Now())
+	if !active {
+		return false
+	}
+	slog.Info("Backup deferred by blackout window", "engine", db.Engine, "cluster", db.ClusterName,
+		"namespace", db.Namespace, "repository", repoName, "until", end)
+	metrics.RecordDeferred(db.Engine, db.ClusterName, db.Namespace, opBackup, windowBlackout)
+	s.deferJob(db.key(), opBackup, repoName, end.Add(windowSlack), func(ctx context.Context, current *ManagedDB) {
+		s.runBackup(ctx, current, repoName)
+	})
+	return true
+}
+
+// deferRepairToMaintenance reports whether auto-repair must wait because the
+// database has maintenance windows and none is open. In that case a triage is
+// scheduled for the next window, which repairs if the database is still
+// unhealthy then.
+func (s *Scheduler) deferRepairToMaintenance(db *ManagedDB, log *slog.Logger) bool {
+	windows := db.Config.MaintenanceWindows
+	if len(windows) == 0 {
+		return false
+	}
+	now := time.Now()
+	if _, active := v1alpha1.ActiveWindow(windows, now); active {
+		return false
+	}
+	metrics.RecordDeferred(db.Engine, db.ClusterName, db.Namespace, opRepair, windowMaintenance)
+	next := v1alpha1.NextWindowStart(windows, now)
+	if next.IsZero() {
+		log.Warn("Auto-repair deferred, but no maintenance window will open")
+		return true
+	}
+	log.Info("Auto-repair deferred to next maintenance window", "at", next)
+	s.deferJob(db.key(), opTriage, "", next.Add(windowSlack), func(ctx context.Context, current *ManagedDB) {
+		s.runTriage(ctx, current)
+	})
+	return true
+}
+
+// deferJob queues fn on the database's job queue at the given time. Only the
+// first deferral of a job id counts until it fires; later ones are dropped,
+// like coalesced queue triggers. Deregistering the database cancels it.
+func (s *Scheduler) deferJob(key, op, target string, at time.Time, fn func(context.Context, *ManagedDB)) {
+	id := op
+	if target != "" {
+		id += "/" + target
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.managed[key]
+	if !ok {
+		return
+	}
+	if _, pending := entry.deferred[id]; pending {
+		return
+	}
+	if entry.deferred == nil {
+		entry.deferred = make(map[string]*time.Timer)
+	}
+	entry.deferred[id] = time.AfterFunc(time.Until(at), func() {
+		s.mu.Lock()
+		if current, ok := s.managed[key]; ok {
+			delete(current.deferred, id)
+		}
+		s.mu.Unlock()
+		s.enqueue(key, op, target, fn)
+	})
+}
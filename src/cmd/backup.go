@@ -5,11 +5,13 @@ import (
 	"time"
 
 	"github.com/PrPlanIT/HASteward/src/engine/backup"
+	"github.com/PrPlanIT/HASteward/src/events"
 	"github.com/PrPlanIT/HASteward/src/output"
 	"github.com/PrPlanIT/HASteward/src/output/model"
 	"github.com/PrPlanIT/HASteward/src/output/printer"
 
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 )
 
 var backupCmd = &cobra.Command{
@@ -42,12 +44,17 @@ var backupCmd = &cobra.Command{
 
 		result, err := backup.Run(cmd.Context(), backer, newSink(p))
 		if err != nil {
+			recordEvent(cmd.Context(), corev1.EventTypeWarning, events.ReasonBackupFailed, "Backup failed: %v", err)
 			if !p.IsHuman() {
 				printer.PrintResult(p, (*model.BackupResult)(nil), nil, err)
 			}
 			return err
 		}
 
+		recordEvent(cmd.Context(), corev1.EventTypeNormal, events.ReasonBackupSucceeded,
+			"Backed up to %s: snapshot %s (%s in %s)", result.Repository, result.SnapshotID,
+			output.FormatBytes(result.Size), result.Duration.Truncate(time.Second))
+
 		if p.IsHuman() {
 			output.Complete(fmt.Sprintf("Backup complete — snapshot %s (%s)", result.SnapshotID, result.Duration.Truncate(time.Second)))
 		} else {
@@ -4,10 +4,12 @@ import (
 	"fmt"
 
 	"github.com/PrPlanIT/HASteward/src/engine/bootstrap"
+	"github.com/PrPlanIT/HASteward/src/events"
 	"github.com/PrPlanIT/HASteward/src/output"
 	"github.com/PrPlanIT/HASteward/src/output/printer"
 
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 )
 
 var bootstrapCmd = &cobra.Command{
@@ -52,6 +54,9 @@ Examples:
 
 		result, err := bootstrap.Run(cmd.Context(), bootstrapper, IsDryRun(), newSink(p))
 		if err != nil {
+			if !IsDryRun() {
+				recordEvent(cmd.Context(), corev1.EventTypeWarning, events.ReasonBootstrapFailed, "Bootstrap failed: %v", err)
+			}
 			if !p.IsHuman() && result != nil {
 				// Return the partial result (includes decision) even on error
 				printer.PrintResult(p, result, nil, err)
@@ -59,6 +64,11 @@ Examples:
 			return err
 		}
 
+		if !IsDryRun() {
+			recordEvent(cmd.Context(), corev1.EventTypeNormal, events.ReasonBootstrapSucceeded,
+				"Bootstrapped from %s (seqno %d)", result.Decision.CandidatePod, result.Decision.CandidateSeqno)
+		}
+
 		if p.IsHuman() {
 			if IsDryRun() {
 				output.Banner("DRY RUN — Bootstrap Plan")
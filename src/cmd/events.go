@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/PrPlanIT/HASteward/src/events"
+)
+
+// recordEvent records a Kubernetes Event on the target database CR when
+// --emit-events is set. The operator always records events; CLI runs opt in.
+func recordEvent(ctx context.Context, eventType, reason, format string, args ...any) {
+	if !emitEvents {
+		return
+	}
+	events.Record(ctx, Cfg.Engine, Cfg.Namespace, Cfg.ClusterName, eventType, reason, format, args...)
+}
@@ -58,12 +58,15 @@ Examples:
 		if Cfg.ResticPassword != "" {
 			common.RegisterSecret(Cfg.ResticPassword)
 		}
+		if err := ResolveResticEnv(); err != nil {
+			return err
+		}
 
 		if err := ResolveInstance(cmd); err != nil {
 			return err
 		}
 
-		rc := restic.NewClient(Cfg.BackupsPath, Cfg.ResticPassword)
+		rc := restic.NewClient(Cfg.BackupsPath, Cfg.ResticPassword, Cfg.ResticEnv)
 
 		var dumpFile string
 		switch Cfg.Engine {
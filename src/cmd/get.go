@@ -10,11 +10,13 @@ import (
 	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
 	"github.com/PrPlanIT/HASteward/src/common"
 	"github.com/PrPlanIT/HASteward/src/k8s"
+	"github.com/PrPlanIT/HASteward/src/output"
 	"github.com/PrPlanIT/HASteward/src/output/model"
 	"github.com/PrPlanIT/HASteward/src/output/printer"
 	"github.com/PrPlanIT/HASteward/src/restic"
 
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -29,13 +31,13 @@ var (
 
 var getCmd = &cobra.Command{
 	Use:   "get",
-	Short: "Display resources (backups, policies, repositories, status)",
+	Short: "Display resources (backups, policies, repositories, status, triage history)",
 }
 
 func init() {
 	getCmd.PersistentFlags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List across all namespaces")
 	getCmd.PersistentFlags().StringVarP(&getType, "type", "t", "all", "Snapshot type filter: backup, diverged, or all")
-	getCmd.AddCommand(getBackupsCmd, getPoliciesCmd, getRepositoriesCmd, getStatusCmd)
+	getCmd.AddCommand(getBackupsCmd, getPoliciesCmd, getRepositoriesCmd, getStatusCmd, getTriageHistoryCmd)
 }
 
 // --- get backups ---
@@ -67,7 +69,11 @@ var getBackupsCmd = &cobra.Command{
 		var entries []model.SnapshotEntry
 
 		if Cfg.BackupsPath != "" && Cfg.ResticPassword != "" {
-			rc := restic.NewClient(Cfg.BackupsPath, Cfg.ResticPassword)
+			common.RegisterSecret(Cfg.ResticPassword)
+			if err := ResolveResticEnv(); err != nil {
+				return err
+			}
+			rc := restic.NewClient(Cfg.BackupsPath, Cfg.ResticPassword, Cfg.ResticEnv)
 			snapshots, err := rc.Snapshots(cmd.Context(), tags)
 			if err != nil {
 				return fmt.Errorf("failed to list snapshots: %w", err)
@@ -78,7 +84,7 @@ var getBackupsCmd = &cobra.Command{
 					Repository: Cfg.BackupsPath, SnapshotID: snap.ShortID,
 					Type: tm["type"], Engine: tm["engine"],
 					Namespace: tm["namespace"], Cluster: tm["cluster"],
-					Age: formatAge(time.Since(snap.Time).Truncate(time.Second)),
+					Age: output.FormatAge(time.Since(snap.Time).Truncate(time.Second)),
 				})
 			}
 		} else {
@@ -103,7 +109,7 @@ var getBackupsCmd = &cobra.Command{
 						Repository: repo.Name, SnapshotID: snap.ShortID,
 						Type: tm["type"], Engine: tm["engine"],
 						Namespace: tm["namespace"], Cluster: tm["cluster"],
-						Age: formatAge(time.Since(snap.Time).Truncate(time.Second)),
+						Age: output.FormatAge(time.Since(snap.Time).Truncate(time.Second)),
 					})
 				}
 			}
@@ -149,6 +155,11 @@ var getPoliciesCmd = &cobra.Command{
 			return fmt.Errorf("failed to list BackupPolicies: %w", err)
 		}
 
+		var nsList v1alpha1.NamespacedBackupPolicyList
+		if err := rtClient.List(cmd.Context(), &nsList, client.InNamespace(Cfg.Namespace)); err != nil {
+			return fmt.Errorf("failed to list NamespacedBackupPolicies: %w", err)
+		}
+
 		var entries []model.PolicyEntry
 		for _, pol := range list.Items {
 			entries = append(entries, model.PolicyEntry{
@@ -159,6 +170,15 @@ var getPoliciesCmd = &cobra.Command{
 				Repositories:   pol.Spec.Repositories,
 			})
 		}
+		for _, pol := range nsList.Items {
+			entries = append(entries, model.PolicyEntry{
+				Name:           pol.Namespace + "/" + pol.Name,
+				BackupSchedule: pol.Spec.BackupSchedule,
+				TriageSchedule: pol.Spec.TriageSchedule,
+				Mode:           pol.Spec.Mode,
+				Repositories:   pol.Spec.Repositories,
+			})
+		}
 
 		if p.IsHuman() {
 			w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
@@ -202,6 +222,10 @@ var getRepositoriesCmd = &cobra.Command{
 
 		var entries []model.RepositoryEntry
 		for _, r := range repos {
+			lastCheck := ""
+			if !r.Status.LastCheck.IsZero() {
+				lastCheck = r.Status.LastCheck.UTC().Format(time.RFC3339)
+			}
 			entries = append(entries, model.RepositoryEntry{
 				Name:             r.Name,
 				Repository:       r.Spec.Restic.Repository,
@@ -209,15 +233,22 @@ var getRepositoriesCmd = &cobra.Command{
 				SnapshotCount:    int64(r.Status.SnapshotCount),
 				TotalSize:        r.Status.TotalSize,
 				DeduplicatedSize: r.Status.DeduplicatedSize,
+				LastCheck:        lastCheck,
+				LastError:        r.Status.LastError,
 			})
 		}
 
 		if p.IsHuman() {
 			w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
-			fmt.Fprintf(w, "NAME\tREPOSITORY\tREADY\tSNAPSHOTS\tTOTAL SIZE\tDEDUP SIZE\n")
-			for _, e := range entries {
-				fmt.Fprintf(w, "%s\t%s\t%v\t%d\t%s\t%s\n",
-					e.Name, e.Repository, e.Ready, e.SnapshotCount, e.TotalSize, e.DeduplicatedSize)
+			fmt.Fprintf(w, "NAME\tREPOSITORY\tREADY\tSNAPSHOTS\tTOTAL SIZE\tDEDUP SIZE\tLAST CHECK\n")
+			for _, r := range repos {
+				lastCheck := "-"
+				if !r.Status.LastCheck.IsZero() {
+					lastCheck = output.FormatAge(time.Since(r.Status.LastCheck.Time).Truncate(time.Second))
+				}
+				fmt.Fprintf(w, "%s\t%s\t%v\t%d\t%s\t%s\t%s\n",
+					r.Name, r.Spec.Restic.Repository, r.Status.Ready, r.Status.SnapshotCount,
+					r.Status.TotalSize, r.Status.DeduplicatedSize, lastCheck)
 			}
 			w.Flush()
 		} else {
@@ -243,11 +274,29 @@ var getStatusCmd = &cobra.Command{
 		}
 
 		var entries []model.ClusterStatusEntry
+		seen := map[string]bool{}
+
+		// ManagedDatabase CRs are the source of truth for operator-managed databases
+		mdList, err := listManagedDatabases(cmd.Context())
+		if err != nil {
+			common.DebugLog("ManagedDatabase list failed, falling back to annotations: %v", err)
+		}
+		for i := range mdList {
+			md := &mdList[i]
+			entries = append(entries, managedDatabaseStatus(md))
+			seen[md.Spec.Engine+"/"+md.Namespace+"/"+md.Spec.ClusterName] = true
+		}
+
+		// Fall back to status annotations for databases without a ManagedDatabase
+		// (managed by an older operator, or not managed at all)
 		c := k8s.GetClients()
 
 		cnpgList, err := c.Dynamic.Resource(k8s.CNPGClusterGVR).Namespace(Cfg.Namespace).List(cmd.Context(), k8s.ListOptions())
 		if err == nil {
 			for _, obj := range cnpgList.Items {
+				if seen["cnpg/"+obj.GetNamespace()+"/"+obj.GetName()] {
+					continue
+				}
 				if e := extractStatus(&obj, "cnpg"); e != nil {
 					entries = append(entries, *e)
 				}
@@ -257,6 +306,9 @@ var getStatusCmd = &cobra.Command{
 		mariaList, err := c.Dynamic.Resource(k8s.MariaDBGVR).Namespace(Cfg.Namespace).List(cmd.Context(), k8s.ListOptions())
 		if err == nil {
 			for _, obj := range mariaList.Items {
+				if seen["galera/"+obj.GetNamespace()+"/"+obj.GetName()] {
+					continue
+				}
 				if e := extractStatus(&obj, "galera"); e != nil {
 					entries = append(entries, *e)
 				}
@@ -265,12 +317,22 @@ var getStatusCmd = &cobra.Command{
 
 		if p.IsHuman() {
 			w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
-			fmt.Fprintf(w, "ENGINE\tNAMESPACE\tCLUSTER\tMANAGED\tSTATUS\tLAST TRIAGE\tLAST BACKUP\n")
+			fmt.Fprintf(w, "ENGINE\tNAMESPACE\tCLUSTER\tMANAGED\tSTATUS\tREADY\tLAST TRIAGE\tLAST BACKUP\tNEXT BACKUP\tRPO\n")
 			for _, e := range entries {
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-					e.Engine, e.Namespace, e.Name, e.Managed, e.TriageResult, e.LastTriage, e.LastBackup)
+				rpo := dash(e.RPO)
+				if len(e.RPOViolations) > 0 {
+					rpo += " VIOLATED"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+					e.Engine, e.Namespace, e.Name, e.Managed, e.TriageResult, dash(e.Ready),
+					e.LastTriage, e.LastBackup, dash(e.NextBackup), rpo)
 			}
 			w.Flush()
+			for _, e := range entries {
+				for _, v := range e.RPOViolations {
+					common.WarnLog("RPO violated for %s/%s %s", e.Namespace, e.Name, v)
+				}
+			}
 		} else {
 			printer.PrintResult(p, &model.GetStatusResult{Clusters: entries}, nil, nil)
 		}
@@ -278,6 +340,38 @@ var getStatusCmd = &cobra.Command{
 	},
 }
 
+// managedDatabaseStatus builds a status entry from a ManagedDatabase CR.
+func managedDatabaseStatus(md *v1alpha1.ManagedDatabase) model.ClusterStatusEntry {
+	e := model.ClusterStatusEntry{
+		Engine: md.Spec.Engine, Namespace: md.Namespace, Name: md.Spec.ClusterName,
+		Managed: "true", TriageResult: "-", LastTriage: "-", LastBackup: "-",
+	}
+	if t := md.Status.Triage; t != nil {
+		e.TriageResult = t.Result
+		e.LastTriage = t.Time.UTC().Format(time.RFC3339)
+		e.Ready = fmt.Sprintf("%d/%d", t.ReadyCount, t.TotalCount)
+	}
+	var lastBackup time.Time
+	for _, b := range md.Status.Backups {
+		if b.LastBackup.After(lastBackup) {
+			lastBackup = b.LastBackup.Time
+		}
+	}
+	if !lastBackup.IsZero() {
+		e.LastBackup = lastBackup.UTC().Format(time.RFC3339)
+	}
+	if r := md.Status.Repair; r != nil {
+		e.LastRepair = r.Time.UTC().Format(time.RFC3339)
+	}
+	if n := md.Status.NextRuns.Backup; n != nil {
+		e.NextBackup = n.UTC().Format(time.RFC3339)
+	}
+	e.RPO = md.Status.EffectiveConfig.RPO
+	e.RPOViolations = md.RPOViolations(time.Now())
+	return e
+}
+
+// extractStatus builds a status entry from the legacy status annotations.
 func extractStatus(obj *unstructured.Unstructured, eng string) *model.ClusterStatusEntry {
 	annotations := obj.GetAnnotations()
 	if annotations == nil {
@@ -348,59 +442,80 @@ func listRepositories(ctx context.Context) ([]v1alpha1.BackupRepository, error)
 	if err := rtClient.List(ctx, &list); err != nil {
 		return nil, fmt.Errorf("failed to list BackupRepositories: %w", err)
 	}
+
+	// NamespacedBackupRepositories are listed as "namespace/name", matching
+	// how they appear in a database's effective config. Their Secrets always
+	// live in their own namespace.
+	var nsList v1alpha1.NamespacedBackupRepositoryList
+	if err := rtClient.List(ctx, &nsList, client.InNamespace(Cfg.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list NamespacedBackupRepositories: %w", err)
+	}
+	repos := list.Items
+	for _, r := range nsList.Items {
+		repo := v1alpha1.BackupRepository{Spec: r.Spec, Status: r.Status}
+		repo.Name = r.Namespace + "/" + r.Name
+		repo.Spec.Restic.PasswordSecretRef.Namespace = r.Namespace
+		if r.Spec.Restic.EnvSecretRef != nil {
+			repo.Spec.Restic.EnvSecretRef = &v1alpha1.SecretRef{Name: r.Spec.Restic.EnvSecretRef.Name, Namespace: r.Namespace}
+		}
+		repos = append(repos, repo)
+	}
+	return repos, nil
+}
+
+func listManagedDatabases(ctx context.Context) ([]v1alpha1.ManagedDatabase, error) {
+	rtClient, err := getRuntimeClient()
+	if err != nil {
+		return nil, err
+	}
+	var list v1alpha1.ManagedDatabaseList
+	if err := rtClient.List(ctx, &list, client.InNamespace(Cfg.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list ManagedDatabases: %w", err)
+	}
 	return list.Items, nil
 }
 
+// dash returns "-" for empty table cells.
+func dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
 func repoClient(ctx context.Context, repo *v1alpha1.BackupRepository) (*restic.Client, error) {
 	rtClient, err := getRuntimeClient()
 	if err != nil {
 		return nil, err
 	}
 
-	secret := &unstructured.Unstructured{}
-	secret.SetGroupVersionKind(k8s.SecretGVK)
 	ref := repo.Spec.Restic.PasswordSecretRef
+	secret := &corev1.Secret{}
 	if err := rtClient.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, secret); err != nil {
 		return nil, fmt.Errorf("password secret %s/%s: %w", ref.Namespace, ref.Name, err)
 	}
 
-	data := k8s.GetNestedMap(secret, "data")
-	pwBytes, ok := data[ref.Key]
+	pwBytes, ok := secret.Data[ref.Key]
 	if !ok {
 		return nil, fmt.Errorf("key %q not found in secret %s/%s", ref.Key, ref.Namespace, ref.Name)
 	}
 
-	pw := fmt.Sprintf("%s", pwBytes)
+	pw := string(pwBytes)
 	common.RegisterSecret(pw)
 
-	rc := restic.NewClient(repo.Spec.Restic.Repository, pw)
-
+	var env map[string]string
 	if repo.Spec.Restic.EnvSecretRef != nil {
 		envRef := repo.Spec.Restic.EnvSecretRef
-		envSecret := &unstructured.Unstructured{}
-		envSecret.SetGroupVersionKind(k8s.SecretGVK)
+		envSecret := &corev1.Secret{}
 		if err := rtClient.Get(ctx, types.NamespacedName{Name: envRef.Name, Namespace: envRef.Namespace}, envSecret); err != nil {
 			return nil, fmt.Errorf("env secret %s/%s: %w", envRef.Namespace, envRef.Name, err)
 		}
-		envData := k8s.GetNestedMap(envSecret, "data")
-		rc.Env = make(map[string]string)
-		for k, v := range envData {
-			rc.Env[k] = fmt.Sprintf("%s", v)
+		env = make(map[string]string, len(envSecret.Data))
+		for k, v := range envSecret.Data {
+			env[k] = string(v)
+			common.RegisterSecret(string(v))
 		}
 	}
 
-	return rc, nil
-}
-
-func formatAge(d time.Duration) string {
-	if d < time.Minute {
-		return fmt.Sprintf("%ds", int(d.Seconds()))
-	}
-	if d < time.Hour {
-		return fmt.Sprintf("%dm", int(d.Minutes()))
-	}
-	if d < 24*time.Hour {
-		return fmt.Sprintf("%dh", int(d.Hours()))
-	}
-	return fmt.Sprintf("%dd", int(d.Hours()/24))
+	return restic.NewClient(repo.Spec.Restic.Repository, pw, env), nil
 }
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	v1alpha1 "github.com/PrPlanIT/HASteward/api/v1alpha1"
+	"github.com/PrPlanIT/HASteward/src/output"
+	"github.com/PrPlanIT/HASteward/src/output/model"
+	"github.com/PrPlanIT/HASteward/src/output/printer"
+
+	"github.com/spf13/cobra"
+)
+
+var getTriageHistoryCmd = &cobra.Command{
+	Use:   "triage-history",
+	Short: "Show recent scheduled triage results of managed databases",
+	Long: `Shows the triage history the operator records in each ManagedDatabase
+(its most recent scheduled triages, newest first): result, ready count, and per
+instance readiness, lag and disk usage. Lag is WAL bytes behind the most
+advanced instance for CNPG and seqno lag for Galera. A primary is marked *.
+
+The number of result changes in the history is shown per database; a high
+count points at a flapping cluster.
+
+Examples:
+  hasteward get triage-history -n zeldas-lullaby
+  hasteward get triage-history -e galera -c osticket-mariadb -n hyrule-castle
+  hasteward get triage-history --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, err := InitPrinter("get-triage-history")
+		if err != nil {
+			return err
+		}
+
+		if err := initGetClients(); err != nil {
+			return err
+		}
+
+		mdList, err := listManagedDatabases(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		var entries []model.TriageHistoryEntry
+		for i := range mdList {
+			md := &mdList[i]
+			if Cfg.Engine != "" && md.Spec.Engine != Cfg.Engine {
+				continue
+			}
+			if Cfg.ClusterName != "" && md.Spec.ClusterName != Cfg.ClusterName {
+				continue
+			}
+			entries = append(entries, triageHistoryEntry(md))
+		}
+
+		if !p.IsHuman() {
+			printer.PrintResult(p, &model.GetTriageHistoryResult{Databases: entries}, nil, nil)
+			return nil
+		}
+		if len(entries) == 0 {
+			fmt.Println("No managed databases found")
+			return nil
+		}
+		for i, e := range entries {
+			if i > 0 {
+				fmt.Println()
+			}
+			printTriageHistory(e)
+		}
+		return nil
+	},
+}
+
+// triageHistoryEntry converts the recorded history of a ManagedDatabase.
+func triageHistoryEntry(md *v1alpha1.ManagedDatabase) model.TriageHistoryEntry {
+	e := model.TriageHistoryEntry{
+		Engine: md.Spec.Engine, Namespace: md.Namespace, Name: md.Spec.ClusterName,
+		History: []model.TriageHistoryPoint{},
+	}
+	for i, snap := range md.Status.TriageHistory {
+		if i > 0 && snap.Result != md.Status.TriageHistory[i-1].Result {
+			e.Transitions++
+		}
+		point := model.TriageHistoryPoint{
+			Time:         snap.Time.UTC().Format(time.RFC3339),
+			Result:       snap.Result,
+			ReadyCount:   snap.ReadyCount,
+			TotalCount:   snap.TotalCount,
+			ClusterPhase: snap.ClusterPhase,
+		}
+		for _, inst := range snap.Instances {
+			point.Instances = append(point.Instances, model.TriageHistoryInstance{
+				Pod: inst.Pod, Ready: inst.Ready, Primary: inst.Primary,
+				Position: inst.Position, Lag: inst.Lag, DiskPct: inst.DiskPct,
+			})
+		}
+		e.History = append(e.History, point)
+	}
+	return e
+}
+
+// printTriageHistory prints one database's history as a table with a column
+// per instance seen anywhere in it.
+func printTriageHistory(e model.TriageHistoryEntry) {
+	fmt.Printf("%s %s/%s: %d triages, %d result changes\n",
+		e.Engine, e.Namespace, e.Name, len(e.History), e.Transitions)
+	if len(e.History) == 0 {
+		return
+	}
+
+	var pods []string
+	for _, point := range e.History {
+		for _, inst := range point.Instances {
+			if !slices.Contains(pods, inst.Pod) {
+				pods = append(pods, inst.Pod)
+			}
+		}
+	}
+	slices.Sort(pods)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "TIME\tRESULT\tREADY\tPHASE")
+	for _, pod := range pods {
+		fmt.Fprintf(w, "\t%s", pod)
+	}
+	fmt.Fprintln(w)
+	for _, point := range e.History {
+		fmt.Fprintf(w, "%s\t%s\t%d/%d\t%s", point.Time, point.Result,
+			point.ReadyCount, point.TotalCount, dash(point.ClusterPhase))
+		for _, pod := range pods {
+			fmt.Fprintf(w, "\t%s", instanceCell(e.Engine, point.Instances, pod))
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+}
+
+// instanceCell formats one instance as "[*]ready|down lag disk%", or "-" if
+// the instance was not part of that triage.
+func instanceCell(engine string, instances []model.TriageHistoryInstance, pod string) string {
+	i := slices.IndexFunc(instances, func(inst model.TriageHistoryInstance) bool { return inst.Pod == pod })
+	if i < 0 {
+		return "-"
+	}
+	inst := instances[i]
+	state := "down"
+	if inst.Ready {
+		state = "ready"
+	}
+	if inst.Primary {
+		state = "*" + state
+	}
+	lag := fmt.Sprintf("lag %d", inst.Lag)
+	if engine == "cnpg" {
+		lag = "lag " + output.FormatBytes(inst.Lag)
+	}
+	return fmt.Sprintf("%s %s %d%%", state, lag, inst.DiskPct)
+}
@@ -2,14 +2,17 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/PrPlanIT/HASteward/src/engine/repair"
+	"github.com/PrPlanIT/HASteward/src/events"
 	"github.com/PrPlanIT/HASteward/src/output"
 	"github.com/PrPlanIT/HASteward/src/output/model"
 	"github.com/PrPlanIT/HASteward/src/output/printer"
 
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 )
 
 var repairCmd = &cobra.Command{
@@ -40,14 +43,25 @@ var repairCmd = &cobra.Command{
 			return err
 		}
 
+		recordEvent(cmd.Context(), corev1.EventTypeNormal, events.ReasonRepairStarted, "Repair started")
+
 		result, err := repair.Run(cmd.Context(), repairer, newSink(p))
 		if err != nil {
+			reason := events.ReasonRepairFailed
+			if repair.IsSafetyGate(err) {
+				reason = events.ReasonRepairRefused
+			}
+			recordEvent(cmd.Context(), corev1.EventTypeWarning, reason, "Repair: %v", err)
 			if !p.IsHuman() {
 				printer.PrintResult(p, (*model.RepairResult)(nil), nil, err)
 			}
 			return err
 		}
 
+		recordEvent(cmd.Context(), corev1.EventTypeNormal, events.ReasonRepairSucceeded,
+			"Repair healed %d instance(s) in %s: %s", len(result.HealedInstances),
+			result.Duration.Truncate(time.Second), strings.Join(result.HealedInstances, ", "))
+
 		if p.IsHuman() {
 			summary := fmt.Sprintf("Repair complete — healed: %d, skipped: %d (%s)",
 				len(result.HealedInstances), len(result.SkippedInstances), result.Duration.Truncate(time.Second))
@@ -5,11 +5,13 @@ import (
 	"time"
 
 	"github.com/PrPlanIT/HASteward/src/engine/restore"
+	"github.com/PrPlanIT/HASteward/src/events"
 	"github.com/PrPlanIT/HASteward/src/output"
 	"github.com/PrPlanIT/HASteward/src/output/model"
 	"github.com/PrPlanIT/HASteward/src/output/printer"
 
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 )
 
 var restoreCmd = &cobra.Command{
@@ -40,14 +42,20 @@ var restoreCmd = &cobra.Command{
 
 		result, err := restore.Run(cmd.Context(), restorer, newSink(p))
 		if err != nil {
+			recordEvent(cmd.Context(), corev1.EventTypeWarning, events.ReasonRestoreFailed, "Restore failed: %v", err)
 			if !p.IsHuman() {
 				printer.PrintResult(p, (*model.RestoreResult)(nil), nil, err)
 			}
 			return err
 		}
 
+		recordEvent(cmd.Context(), corev1.EventTypeNormal, events.ReasonRestoreSucceeded,
+			"Restored snapshot %s from %s (%s in %s)", result.SnapshotID, Cfg.BackupsPath,
+			output.FormatBytes(result.BytesRestored), result.Duration.Truncate(time.Second))
+
 		if p.IsHuman() {
-			output.Complete(fmt.Sprintf("Restore complete — snapshot %s (%s)", result.SnapshotID, result.Duration.Truncate(time.Second)))
+			output.Complete(fmt.Sprintf("Restore complete — snapshot %s, %s (%s)", result.SnapshotID,
+				output.FormatBytes(result.BytesRestored), result.Duration.Truncate(time.Second)))
 		} else {
 			printer.PrintResult(p, result, nil, nil)
 		}
@@ -25,6 +25,15 @@ var outputMode string
 // dryRun holds the --dry-run flag state.
 var dryRun bool
 
+// resticEnvPairs and resticEnvFile hold the raw --restic-env / --restic-env-file values.
+var (
+	resticEnvPairs []string
+	resticEnvFile  string
+)
+
+// emitEvents holds the --emit-events flag state.
+var emitEvents bool
+
 // P is the active printer for the current command invocation.
 var P *printer.Printer
 
@@ -52,6 +61,11 @@ func init() {
 			"required to declare the authoritative source node.")
 	pf.StringVar(&Cfg.BackupsPath, "backups-path", common.Env("BACKUPS_PATH", ""), "Restic repository path or URL")
 	pf.StringVar(&Cfg.ResticPassword, "restic-password", common.EnvRaw("RESTIC_PASSWORD", common.Env("RESTIC_PASSWORD", "")), "Restic repository encryption password")
+	pf.StringArrayVar(&resticEnvPairs, "restic-env", nil,
+		"Extra restic environment variable KEY=VALUE (repeatable). Used for backend\n"+
+			"credentials such as AWS_ACCESS_KEY_ID, B2_ACCOUNT_KEY or RESTIC_REST_PASSWORD.")
+	pf.StringVar(&resticEnvFile, "restic-env-file", common.Env("RESTIC_ENV_FILE", ""),
+		"File of KEY=VALUE restic environment variables (applied before --restic-env)")
 	pf.BoolVar(&Cfg.NoEscrow, "no-escrow", common.EnvBool("NO_ESCROW", false), "Skip pre-repair escrow backup")
 	pf.BoolVar(&Cfg.WipeDatadir, "wipe-datadir", common.EnvBool("WIPE_DATADIR", false),
 		"Wipe entire datadir on target instance (not just grastate). Forces full SST\n"+
@@ -67,6 +81,8 @@ func init() {
 	pf.StringVar(&Cfg.Kubeconfig, "kubeconfig", common.EnvRaw("KUBECONFIG", ""), "Path to kubeconfig file")
 	pf.BoolVarP(&Cfg.Verbose, "verbose", "v", common.EnvBool("VERBOSE", false), "Verbose output (debug logging)")
 	pf.BoolVar(&dryRun, "dry-run", false, "Show planned actions without executing (destructive commands)")
+	pf.BoolVar(&emitEvents, "emit-events", common.EnvBool("EMIT_EVENTS", false),
+		"Record Kubernetes Events on the database CR for backup, triage, repair and bootstrap outcomes")
 	pf.StringVar(&outputMode, "output", common.Env("OUTPUT", "auto"), "Output format: auto, human, json, jsonl")
 	pf.Bool("no-color", false, "Disable color output")
 	pf.Bool("debug", false, "Enable debug output")
@@ -130,6 +146,37 @@ func ResolveDonor(cmd *cobra.Command) error {
 	return nil
 }
 
+// ResolveResticEnv builds Cfg.ResticEnv from --restic-env-file and --restic-env.
+// Values are registered as secrets so credentials never reach the logs.
+func ResolveResticEnv() error {
+	env := map[string]string{}
+	if resticEnvFile != "" {
+		fileEnv, err := common.ParseEnvFile(resticEnvFile)
+		if err != nil {
+			return fmt.Errorf("--restic-env-file: %w", err)
+		}
+		for k, v := range fileEnv {
+			env[k] = v
+		}
+	}
+	for _, pair := range resticEnvPairs {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || k == "" {
+			return fmt.Errorf("--restic-env must be KEY=VALUE, got %q", pair)
+		}
+		env[k] = v
+	}
+	for _, v := range env {
+		common.RegisterSecret(v)
+	}
+	if len(env) == 0 {
+		Cfg.ResticEnv = nil
+		return nil
+	}
+	Cfg.ResticEnv = env
+	return nil
+}
+
 // PreRun validates required flags, initializes K8s clients, and resolves the engine provider.
 func PreRun(cmd *cobra.Command, mode string) (provider.EngineProvider, error) {
 	Cfg.Mode = mode
@@ -148,6 +195,9 @@ func PreRun(cmd *cobra.Command, mode string) (provider.EngineProvider, error) {
 	if Cfg.ResticPassword != "" {
 		common.RegisterSecret(Cfg.ResticPassword)
 	}
+	if err := ResolveResticEnv(); err != nil {
+		return nil, err
+	}
 
 	var missing []string
 	if Cfg.Engine == "" {
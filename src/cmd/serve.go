@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"os"
+	"time"
 
 	"github.com/PrPlanIT/HASteward/src/common"
 	"github.com/PrPlanIT/HASteward/controller"
@@ -9,6 +10,22 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	leaderElect             bool
+	leaderElectionNamespace string
+	maxConcurrentBackups    int
+	maxConcurrentTriages    int
+	repositoryConcurrency   int
+	scheduleSplay           int
+	shutdownGracePeriod     int
+	webhookEnabled          bool
+	webhookCertDir          string
+	apiEnabled              bool
+	apiCertDir              string
+	apiInsecure             bool
+	dashboardEnabled        bool
+)
+
 var serveCmd = &cobra.Command{
 	Use:   "serve",
 	Short: "Run the hasteward operator (controller + scheduler)",
@@ -16,16 +33,75 @@ var serveCmd = &cobra.Command{
 for clinic.hasteward.prplanit.com/policy annotations and automatically runs scheduled backups
 and triage/repair operations based on BackupPolicy configuration.
 
+With --leader-elect, replicas compete for a coordination Lease and only the
+leader runs controllers and scheduled jobs. Standby replicas serve probes and
+metrics and take over when the leader's Lease expires.
+
 Endpoints:
   :8080/metrics   Prometheus metrics
   :8081/healthz   Liveness probe
-  :8081/readyz    Readiness probe`,
+  :8081/readyz    Readiness probe
+  :8082/api/v1    Operator HTTP API (with --api)
+  :8082/          Read-only web dashboard (with --dashboard)
+  :9443           Validating admission webhook (with --webhook)`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if Cfg.Verbose {
 			os.Setenv(common.EnvPrefix+"LOG_LEVEL", "debug")
 		}
 		common.InitLogging(true)
 
-		return controller.Run(cmd.Context(), Cfg.Kubeconfig)
+		return controller.Run(cmd.Context(), controller.Options{
+			Kubeconfig:              Cfg.Kubeconfig,
+			LeaderElection:          leaderElect,
+			LeaderElectionNamespace: leaderElectionNamespace,
+			Scheduler: controller.SchedulerOptions{
+				MaxConcurrentBackups:  maxConcurrentBackups,
+				MaxConcurrentTriages:  maxConcurrentTriages,
+				RepositoryConcurrency: repositoryConcurrency,
+				Splay:                 time.Duration(scheduleSplay) * time.Second,
+				ShutdownGracePeriod:   time.Duration(shutdownGracePeriod) * time.Second,
+			},
+			Webhook:        webhookEnabled,
+			WebhookCertDir: webhookCertDir,
+			API:            apiEnabled,
+			APICertDir:     apiCertDir,
+			APIInsecure:    apiInsecure,
+			Dashboard:      dashboardEnabled,
+		})
 	},
 }
+
+func init() {
+	serveCmd.Flags().BoolVar(&leaderElect, "leader-elect", common.EnvBool("LEADER_ELECT", false),
+		"Enable leader election (required when running more than one replica)")
+	serveCmd.Flags().StringVar(&leaderElectionNamespace, "leader-election-namespace",
+		common.Env("LEADER_ELECTION_NAMESPACE", common.EnvRaw("POD_NAMESPACE", "")),
+		"Namespace for the leader election Lease (default: the pod's namespace)")
+	serveCmd.Flags().IntVar(&maxConcurrentBackups, "max-concurrent-backups",
+		common.EnvInt("MAX_CONCURRENT_BACKUPS", controller.DefaultMaxConcurrentBackups),
+		"Maximum scheduled backups running at once across all databases (0 = unlimited)")
+	serveCmd.Flags().IntVar(&maxConcurrentTriages, "max-concurrent-triages",
+		common.EnvInt("MAX_CONCURRENT_TRIAGES", controller.DefaultMaxConcurrentTriages),
+		"Maximum scheduled triages (and auto-repairs) running at once (0 = unlimited)")
+	serveCmd.Flags().IntVar(&repositoryConcurrency, "repository-concurrency",
+		common.EnvInt("REPOSITORY_CONCURRENCY", controller.DefaultRepositoryConcurrency),
+		"Default maximum backups writing to one repository at once (overridden by spec.maxConcurrency)")
+	serveCmd.Flags().IntVar(&scheduleSplay, "schedule-splay",
+		common.EnvInt("SCHEDULE_SPLAY", int(controller.DefaultScheduleSplay.Seconds())),
+		"Maximum per-database delay in seconds added to cron schedules (0 = fire exactly on schedule)")
+	serveCmd.Flags().IntVar(&shutdownGracePeriod, "shutdown-grace-period",
+		common.EnvInt("SHUTDOWN_GRACE_PERIOD", int(controller.DefaultShutdownGracePeriod.Seconds())),
+		"Seconds to wait on shutdown for running jobs before cancelling them (they then clean up: unfence, resume CR, delete helper pods)")
+	serveCmd.Flags().BoolVar(&webhookEnabled, "webhook", common.EnvBool("WEBHOOK", false),
+		"Serve the validating admission webhook on :9443 (requires a serving certificate)")
+	serveCmd.Flags().StringVar(&webhookCertDir, "webhook-cert-dir", common.Env("WEBHOOK_CERT_DIR", ""),
+		"Directory containing tls.crt and tls.key for the webhook (default: /tmp/k8s-webhook-server/serving-certs)")
+	serveCmd.Flags().BoolVar(&apiEnabled, "api", common.EnvBool("API", false),
+		"Serve the operator HTTP API (status and triggers) on :8082")
+	serveCmd.Flags().StringVar(&apiCertDir, "api-cert-dir", common.Env("API_CERT_DIR", ""),
+		"Directory containing tls.crt and tls.key for the API (required unless --api-insecure)")
+	serveCmd.Flags().BoolVar(&apiInsecure, "api-insecure", common.EnvBool("API_INSECURE", false),
+		"Serve the API and dashboard over plain HTTP when --api-cert-dir is not set (bearer tokens travel in cleartext)")
+	serveCmd.Flags().BoolVar(&dashboardEnabled, "dashboard", common.EnvBool("DASHBOARD", false),
+		"Serve the read-only web dashboard on :8082 (implies --api)")
+}
@@ -1,12 +1,16 @@
 package cmd
 
 import (
+	"strings"
+
 	"github.com/PrPlanIT/HASteward/src/engine/triage"
+	"github.com/PrPlanIT/HASteward/src/events"
 	"github.com/PrPlanIT/HASteward/src/output"
 	"github.com/PrPlanIT/HASteward/src/output/model"
 	"github.com/PrPlanIT/HASteward/src/output/printer"
 
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 )
 
 var triageCmd = &cobra.Command{
@@ -36,6 +40,11 @@ var triageCmd = &cobra.Command{
 			return err
 		}
 
+		if !result.DataComparison.SafeToHeal && len(result.DataComparison.SplitBrainDetails) > 0 {
+			recordEvent(cmd.Context(), corev1.EventTypeWarning, events.ReasonSplitBrainDetected,
+				"Split-brain detected: %s", strings.Join(result.DataComparison.SplitBrainDetails, "; "))
+		}
+
 		if p.IsHuman() {
 			output.Complete("Triage complete")
 		} else {
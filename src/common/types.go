@@ -31,8 +31,9 @@ type Config struct {
 	BackupsPath    string
 	NoEscrow       bool
 	BackupMethod   string
-	Snapshot       string // Restic snapshot ID or "latest" (for restore)
-	ResticPassword string // Restic repository encryption password
+	Snapshot       string            // Restic snapshot ID or "latest" (for restore)
+	ResticPassword string            // Restic repository encryption password
+	ResticEnv      map[string]string // Extra restic environment (S3/B2/rest-server credentials)
 	HealTimeout    int
 	DeleteTimeout  int
 	Kubeconfig     string
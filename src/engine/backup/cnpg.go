@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/PrPlanIT/HASteward/src/common"
+	"github.com/PrPlanIT/HASteward/src/engine"
 	"github.com/PrPlanIT/HASteward/src/engine/provider"
 	"github.com/PrPlanIT/HASteward/src/k8s"
 	"github.com/PrPlanIT/HASteward/src/output"
@@ -52,7 +53,7 @@ func (b *cnpgBackup) Backup(ctx context.Context) (*model.BackupResult, error) {
 // newResticClient creates a restic client from the current config.
 func (b *cnpgBackup) newResticClient() *restic.Client {
 	cfg := b.p.Config()
-	return restic.NewClient(cfg.BackupsPath, cfg.ResticPassword)
+	return restic.NewClient(cfg.BackupsPath, cfg.ResticPassword, cfg.ResticEnv)
 }
 
 // BackupDump streams pg_dumpall from a donor pod through restic backup --stdin.
@@ -181,7 +182,9 @@ func (b *cnpgBackup) backupNative(ctx context.Context) (*model.BackupResult, err
 	// Wait for completion (120 retries * 10s = 20 min max)
 	common.InfoLog("Waiting for backup %s to complete...", backupName)
 	for i := 0; i < 120; i++ {
-		time.Sleep(10 * time.Second)
+		if err := engine.Sleep(ctx, 10*time.Second); err != nil {
+			return nil, fmt.Errorf("waiting for backup %s: %w", backupName, err)
+		}
 		obj, err := c.Dynamic.Resource(gvr).Namespace(cfg.Namespace).Get(ctx, backupName, metav1.GetOptions{})
 		if err != nil {
 			continue
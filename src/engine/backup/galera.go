@@ -45,7 +45,7 @@ func (b *galeraBackup) Backup(ctx context.Context) (*model.BackupResult, error)
 // newResticClient creates a restic client from the current config.
 func (b *galeraBackup) newResticClient() *restic.Client {
 	cfg := b.p.Config()
-	return restic.NewClient(cfg.BackupsPath, cfg.ResticPassword)
+	return restic.NewClient(cfg.BackupsPath, cfg.ResticPassword, cfg.ResticEnv)
 }
 
 // BackupDump streams mysqldump from a donor pod through restic backup --stdin.
@@ -181,7 +181,7 @@ func (r *cnpgRepair) planTargeted(ctx context.Context, result *model.TriageResul
 
 	// Safety gate: target is primary -> HARD STOP
 	if targetPod == primary {
-		return nil, fmt.Errorf("ABORT: %s is the PRIMARY. Cannot heal primary. Use switchover first", targetPod)
+		return nil, refuse(fmt.Errorf("ABORT: %s is the PRIMARY. Cannot heal primary. Use switchover first", targetPod))
 	}
 
 	// Find target assessment
@@ -198,7 +198,7 @@ func (r *cnpgRepair) planTargeted(ctx context.Context, result *model.TriageResul
 
 	// Safety gate: split-brain -> fail unless force
 	if !result.DataComparison.SafeToHeal && !cfg.Force {
-		return nil, fmt.Errorf("ABORT: Split-brain detected. Healing %s may cause DATA LOSS. Re-run with --force to override", targetPod)
+		return nil, refuse(fmt.Errorf("ABORT: Split-brain detected. Healing %s may cause DATA LOSS. Re-run with --force to override", targetPod))
 	}
 	if !result.DataComparison.SafeToHeal && cfg.Force {
 		common.WarnLog("force=true - proceeding despite split-brain detection. Data on %s will be DESTROYED", targetPod)
@@ -234,8 +234,8 @@ func (r *cnpgRepair) planTargeted(ctx context.Context, result *model.TriageResul
 func (r *cnpgRepair) planUntargeted(ctx context.Context, result *model.TriageResult) ([]HealTarget, error) {
 	// Safety gate: split-brain -> HARD STOP (no override for untargeted)
 	if !result.DataComparison.SafeToHeal {
-		return nil, fmt.Errorf("HARD STOP: Split-brain detected. Cannot auto-heal all replicas. " +
-			"Admin must review triage output, then use targeted repair: --instance <N>")
+		return nil, refuse(fmt.Errorf("HARD STOP: Split-brain detected. Cannot auto-heal all replicas. " +
+			"Admin must review triage output, then use targeted repair: --instance <N>"))
 	}
 
 	var targets []HealTarget
@@ -277,7 +277,9 @@ func (r *cnpgRepair) Heal(ctx context.Context, target HealTarget) error {
 func (r *cnpgRepair) Stabilize(ctx context.Context) error {
 	output.Section("Post-Repair Stabilization")
 	common.InfoLog("Waiting 30s for CNPG operator to reconcile...")
-	time.Sleep(30 * time.Second)
+	if err := engine.Sleep(ctx, 30*time.Second); err != nil {
+		return err
+	}
 	r.waitForAllReady(ctx)
 	return nil
 }
@@ -313,6 +315,7 @@ func (r *cnpgRepair) healInstance(ctx context.Context, targetPod, targetPVC stri
 
 	fenceApplied := false
 	healPodCreated := false
+	pvcTouched := false // a heal pod may have mounted the PVC
 
 	output.Section("Healing " + targetPod)
 	output.Bullet(0, "1. Fence instance (CNPG stops managing it)")
@@ -321,14 +324,25 @@ func (r *cnpgRepair) healInstance(ctx context.Context, targetPod, targetPVC stri
 	output.Bullet(0, "4. Run pg_basebackup from primary (%s)", hcfg.primaryIP)
 	output.Bullet(0, "5. Remove fence (CNPG takes over the replica)")
 
-	// Cleanup function for rescue on error
+	// Cleanup function for rescue on error. Runs on its own context so it
+	// still completes when ctx was cancelled mid-heal.
 	cleanup := func() {
+		cctx, cancel := engine.CleanupContext(ctx)
+		defer cancel()
 		if healPodCreated {
-			_ = c.Clientset.CoreV1().Pods(ns).Delete(ctx, healPodName, metav1.DeleteOptions{
+			_ = c.Clientset.CoreV1().Pods(ns).Delete(cctx, healPodName, metav1.DeleteOptions{
 				GracePeriodSeconds: ptr(int64(0)),
 			})
 			common.InfoLog("Heal pod %s deleted", healPodName)
 		}
+		if fenceApplied && !pvcTouched {
+			// No heal pod ever mounted the PVC, so the data is untouched
+			// and the instance can go back to CNPG
+			if err := r.unfenceInstance(cctx, targetPod); err == nil {
+				common.WarnLog("HEAL FAILED before touching %s. Fence removed.", targetPod)
+				return
+			}
+		}
 		if fenceApplied {
 			common.WarnLog("HEAL FAILED - fence left in place for safety. Instance %s is still fenced.", targetPod)
 			common.WarnLog("To remove fence: kubectl annotate cluster %s -n %s cnpg.io/fencedInstances-", cfg.ClusterName, ns)
@@ -341,7 +355,10 @@ func (r *cnpgRepair) healInstance(ctx context.Context, targetPod, targetPVC stri
 		return fmt.Errorf("failed to fence %s: %w", targetPod, err)
 	}
 	fenceApplied = true
-	time.Sleep(3 * time.Second)
+	if err := engine.Sleep(ctx, 3*time.Second); err != nil {
+		cleanup()
+		return err
+	}
 
 	// STEP 2: Create heal pod
 	common.InfoLog("STEP 2: Creating heal pod %s", healPodName)
@@ -440,7 +457,11 @@ echo "=== pg_basebackup complete! ==="`, hcfg.primaryIP)
 		return fmt.Errorf("failed to create heal pod: %w", err)
 	}
 	healPodCreated = true
-	time.Sleep(2 * time.Second)
+	pvcTouched = true
+	if err := engine.Sleep(ctx, 2*time.Second); err != nil {
+		cleanup()
+		return err
+	}
 
 	// STEP 3: Aggressively delete target pod until heal pod acquires PVC
 	common.InfoLog("STEP 3: Aggressively deleting %s until heal pod acquires PVC", targetPod)
@@ -481,7 +502,10 @@ echo "=== pg_basebackup complete! ==="`, hcfg.primaryIP)
 			common.DebugLog("Deleted %d times, heal pod status: %s", deleteCount, phase)
 		}
 
-		time.Sleep(1 * time.Second)
+		if err := engine.Sleep(ctx, 1*time.Second); err != nil {
+			cleanup()
+			return err
+		}
 	}
 
 	if !acquired {
@@ -499,7 +523,10 @@ echo "=== pg_basebackup complete! ==="`, hcfg.primaryIP)
 
 	succeeded := false
 	for i := 0; i < healTimeout/10; i++ {
-		time.Sleep(10 * time.Second)
+		if err := engine.Sleep(ctx, 10*time.Second); err != nil {
+			cleanup()
+			return err
+		}
 		hp, hpErr := c.Clientset.CoreV1().Pods(ns).Get(ctx, healPodName, metav1.GetOptions{})
 		if hpErr != nil {
 			continue
@@ -534,7 +561,10 @@ echo "=== pg_basebackup complete! ==="`, hcfg.primaryIP)
 		GracePeriodSeconds: ptr(int64(0)),
 	})
 	healPodCreated = false
-	time.Sleep(5 * time.Second)
+	if err := engine.Sleep(ctx, 5*time.Second); err != nil {
+		cleanup()
+		return err
+	}
 
 	// STEP 5: Remove fence (only our target, preserve others)
 	common.InfoLog("STEP 5: Removing fence for %s", targetPod)
@@ -547,12 +577,16 @@ echo "=== pg_basebackup complete! ==="`, hcfg.primaryIP)
 	_ = c.Clientset.CoreV1().Pods(ns).Delete(ctx, targetPod, metav1.DeleteOptions{
 		GracePeriodSeconds: ptr(int64(0)),
 	})
-	time.Sleep(5 * time.Second)
+	if err := engine.Sleep(ctx, 5*time.Second); err != nil {
+		return fmt.Errorf("interrupted waiting for %s to come back online: %w", targetPod, err)
+	}
 
 	// Wait for pod to come back online
 	common.InfoLog("Waiting for %s to come back online", targetPod)
 	for i := 0; i < 30; i++ {
-		time.Sleep(10 * time.Second)
+		if err := engine.Sleep(ctx, 10*time.Second); err != nil {
+			return fmt.Errorf("interrupted waiting for %s to come back online: %w", targetPod, err)
+		}
 		pod, podErr := c.Clientset.CoreV1().Pods(ns).Get(ctx, targetPod, metav1.GetOptions{})
 		if podErr == nil && pod.Status.Phase == "Running" &&
 			len(pod.Status.ContainerStatuses) > 0 && pod.Status.ContainerStatuses[0].Ready {
@@ -706,7 +740,9 @@ func (r *cnpgRepair) waitForAllReady(ctx context.Context) {
 			}
 			common.DebugLog("Ready: %d/%d", ready, expected)
 		}
-		time.Sleep(10 * time.Second)
+		if engine.Sleep(ctx, 10*time.Second) != nil {
+			return
+		}
 	}
 	common.WarnLog("Not all pods became ready within timeout")
 }
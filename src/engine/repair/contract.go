@@ -10,6 +10,8 @@ import (
 type Repairer interface {
 	Name() string
 	Assess(ctx context.Context) (*model.TriageResult, error)
+	// SafetyGate returns a refuse()d error when it decides the repair is
+	// unsafe; any other error is an ordinary failure.
 	SafetyGate(ctx context.Context, triage *model.TriageResult) error
 	Escrow(ctx context.Context, triage *model.TriageResult) error
 	PlanTargets(ctx context.Context, triage *model.TriageResult) ([]HealTarget, error)
@@ -18,6 +20,21 @@ type Repairer interface {
 	Reassess(ctx context.Context) (*model.TriageResult, error)
 }
 
+// DonorReporter is implemented by repairers that resolve a donor in
+// SafetyGate (Galera). Donor returns nil until one has been resolved.
+type DonorReporter interface {
+	Donor() *DonorSelection
+}
+
+// Aborter is implemented by repairers that change cluster state before the
+// first heal (Galera suspends the CR in SafetyGate). Run calls Abort when it
+// stops at or after SafetyGate without healing every target (a failed or
+// cancelled phase, or nothing to heal) so that state is undone. ctx is a cleanup context
+// that outlives a cancelled run.
+type Aborter interface {
+	Abort(ctx context.Context)
+}
+
 // HealTarget identifies a single instance to heal.
 type HealTarget struct {
 	Pod         string
@@ -0,0 +1,25 @@
+package repair
+
+import "errors"
+
+// SafetyGateError marks a repair that a safety check refused to start, as
+// opposed to one that failed while executing. Error() returns the refusal
+// message unchanged.
+type SafetyGateError struct {
+	Err error
+}
+
+func (e *SafetyGateError) Error() string { return e.Err.Error() }
+
+func (e *SafetyGateError) Unwrap() error { return e.Err }
+
+// IsSafetyGate reports whether err is (or wraps) a safety-gate refusal.
+func IsSafetyGate(err error) bool {
+	var sg *SafetyGateError
+	return errors.As(err, &sg)
+}
+
+// refuse wraps err as a safety-gate refusal.
+func refuse(err error) error {
+	return &SafetyGateError{Err: err}
+}
@@ -53,6 +53,23 @@ type galeraRepair struct {
 
 func (g *galeraRepair) Name() string { return "galera" }
 
+// Donor returns the donor resolved in SafetyGate, if any.
+func (g *galeraRepair) Donor() *DonorSelection { return g.donorSelection }
+
+// Abort resumes the CR if SafetyGate left it suspended and no heal has
+// resumed it since.
+func (g *galeraRepair) Abort(ctx context.Context) {
+	if !g.crSuspended {
+		return
+	}
+	if err := g.resumeCR(ctx); err != nil {
+		common.ErrorLog("Failed to resume CR %s after aborted repair: %v", g.p.Config().ClusterName, err)
+		return
+	}
+	g.crSuspended = false
+	common.InfoLog("CR resumed (suspended since SafetyGate)")
+}
+
 // Assess runs a full triage of the Galera cluster.
 func (g *galeraRepair) Assess(ctx context.Context) (*model.TriageResult, error) {
 	output.Section("Phase 1: Triage")
@@ -68,8 +85,8 @@ func (g *galeraRepair) SafetyGate(ctx context.Context, result *model.TriageResul
 	// cluster authority. Checks both AllNodesDown AND empty PrimaryMembers
 	// (nodes can be running but not in a primary component).
 	if result.AllNodesDown || len(result.DataComparison.PrimaryMembers) == 0 {
-		return fmt.Errorf("ABORT: No primary component exists (cluster has no join target). " +
-			"Use 'hasteward bootstrap' to declare authority explicitly. --force cannot override this")
+		return refuse(fmt.Errorf("ABORT: No primary component exists (cluster has no join target). " +
+			"Use 'hasteward bootstrap' to declare authority explicitly. --force cannot override this"))
 	}
 
 	// Suspend CR before donor probe — operator recovery pods can interfere
@@ -79,18 +96,28 @@ func (g *galeraRepair) SafetyGate(ctx context.Context, result *model.TriageResul
 		return fmt.Errorf("failed to suspend CR for donor probe: %w", err)
 	}
 	g.crSuspended = true
-	time.Sleep(3 * time.Second)
+	if err := engine.Sleep(ctx, 3*time.Second); err != nil {
+		return err // Run aborts, resuming the CR
+	}
 
 	// Delete any active recovery pods that may be competing with mariadb containers
 	g.deleteRecoveryPods(ctx)
-	time.Sleep(2 * time.Second)
+	if err := engine.Sleep(ctx, 2*time.Second); err != nil {
+		return err // Run aborts, resuming the CR
+	}
 
 	output.Section("Phase 2: Donor Resolution")
 	ds, err := g.resolveRepairDonor(ctx, result)
 	if err != nil {
 		// Resume CR on failure so cluster isn't left suspended
-		g.resumeCR(ctx)
+		cctx, cancel := engine.CleanupContext(ctx)
+		defer cancel()
+		g.resumeCR(cctx)
 		g.crSuspended = false
+		if ctx.Err() != nil {
+			// A probe cut short by cancellation is not a donor verdict
+			return fmt.Errorf("donor resolution interrupted: %w", ctx.Err())
+		}
 		return err
 	}
 	g.donorSelection = ds
@@ -181,7 +208,7 @@ func (g *galeraRepair) planTargeted(ctx context.Context, result *model.TriageRes
 
 	// Safety gate: split-brain -> fail unless force
 	if !result.DataComparison.SafeToHeal && !cfg.Force {
-		return nil, fmt.Errorf("ABORT: Split-brain detected. Healing %s may cause DATA LOSS. Re-run with --force to override", targetPod)
+		return nil, refuse(fmt.Errorf("ABORT: Split-brain detected. Healing %s may cause DATA LOSS. Re-run with --force to override", targetPod))
 	}
 	if !result.DataComparison.SafeToHeal && cfg.Force {
 		common.WarnLog("force=true - proceeding despite split-brain detection. Data on %s will be DESTROYED", targetPod)
@@ -218,8 +245,8 @@ func (g *galeraRepair) planTargeted(ctx context.Context, result *model.TriageRes
 func (g *galeraRepair) planUntargeted(ctx context.Context, result *model.TriageResult) ([]HealTarget, error) {
 	// Safety gate: split-brain -> HARD STOP (no override for untargeted)
 	if !result.DataComparison.SafeToHeal {
-		return nil, fmt.Errorf("HARD STOP: Split-brain detected. Cannot auto-heal all nodes. " +
-			"Admin must review triage output, then use targeted repair: --instance <N>")
+		return nil, refuse(fmt.Errorf("HARD STOP: Split-brain detected. Cannot auto-heal all nodes. " +
+			"Admin must review triage output, then use targeted repair: --instance <N>"))
 	}
 
 	var targets []HealTarget
@@ -260,7 +287,9 @@ func (g *galeraRepair) Heal(ctx context.Context, target HealTarget) error {
 func (g *galeraRepair) Stabilize(ctx context.Context) error {
 	output.Section("Post-Repair Stabilization")
 	common.InfoLog("Waiting 30s for MariaDB operator to reconcile...")
-	time.Sleep(30 * time.Second)
+	if err := engine.Sleep(ctx, 30*time.Second); err != nil {
+		return err
+	}
 	g.waitForAllReady(ctx)
 	return nil
 }
@@ -294,10 +323,10 @@ func (g *galeraRepair) healNode(ctx context.Context, targetPod string, instanceN
 	// without affecting other pods. Lower ordinals require cluster-scoped recovery.
 	replicas := int(g.p.Replicas())
 	if instanceNum < replicas-1 {
-		return fmt.Errorf("ABORT: Repairing ordinal %d requires removing ordinals %d–%d due to "+
+		return refuse(fmt.Errorf("ABORT: Repairing ordinal %d requires removing ordinals %d–%d due to "+
 			"StatefulSet ordering. This is cluster-impacting and not allowed in instance-scoped repair. "+
 			"Use a cluster-scoped recovery operation for non-highest ordinals",
-			instanceNum, instanceNum+1, replicas-1)
+			instanceNum, instanceNum+1, replicas-1))
 	}
 
 	// Capture SA from target pod before it gets deleted
@@ -378,7 +407,7 @@ func (g *galeraRepair) healNode(ctx context.Context, targetPod string, instanceN
 		}
 	}
 	if !joinTargetExists {
-		return fmt.Errorf("ABORT: No other running nodes in cluster. This is a bootstrap scenario, not repair. Use 'hasteward bootstrap' instead")
+		return refuse(fmt.Errorf("ABORT: No other running nodes in cluster. This is a bootstrap scenario, not repair. Use 'hasteward bootstrap' instead"))
 	}
 
 	output.Section("Healing " + targetPod)
@@ -397,21 +426,25 @@ func (g *galeraRepair) healNode(ctx context.Context, targetPod string, instanceN
 	}
 	output.Bullet(0, "5. Resume CR (operator recreates pod → joins cluster)")
 
-	// Rescue cleanup function — restores scale + resumes CR.
+	// Rescue cleanup function — restores scale + resumes CR. Runs on its
+	// own context so it still completes when ctx was cancelled mid-heal.
 	rescue := func() {
-		_ = c.Clientset.CoreV1().Pods(ns).Delete(ctx, storageHelper, metav1.DeleteOptions{
+		cctx, cancel := engine.CleanupContext(ctx)
+		defer cancel()
+		_ = c.Clientset.CoreV1().Pods(ns).Delete(cctx, storageHelper, metav1.DeleteOptions{
 			GracePeriodSeconds: ptr(int64(0)),
 		})
 		if hasGaleraPVC {
-			_ = c.Clientset.CoreV1().Pods(ns).Delete(ctx, galeraHelper, metav1.DeleteOptions{
+			_ = c.Clientset.CoreV1().Pods(ns).Delete(cctx, galeraHelper, metav1.DeleteOptions{
 				GracePeriodSeconds: ptr(int64(0)),
 			})
 		}
 		if scaledDown {
-			g.scaleStatefulSet(ctx, originalReplicas)
+			g.scaleStatefulSet(cctx, originalReplicas)
 		}
 		if suspended {
-			g.resumeCR(ctx)
+			g.resumeCR(cctx)
+			g.crSuspended = false
 		}
 		if suspended || scaledDown {
 			common.WarnLog("HEAL FAILED for %s. Scale restored, CR resumed.", targetPod)
@@ -428,7 +461,10 @@ func (g *galeraRepair) healNode(ctx context.Context, targetPod string, instanceN
 			return fmt.Errorf("failed to suspend CR: %w", err)
 		}
 		suspended = true
-		time.Sleep(3 * time.Second)
+		if err := engine.Sleep(ctx, 3*time.Second); err != nil {
+			rescue()
+			return err
+		}
 	}
 
 	// STEP 2: Release target pod's PVC by scaling down the StatefulSet.
@@ -459,7 +495,10 @@ func (g *galeraRepair) healNode(ctx context.Context, targetPod string, instanceN
 			}
 			common.DebugLog("Waiting for %s: transient error: %v", targetPod, err)
 		}
-		time.Sleep(5 * time.Second)
+		if err := engine.Sleep(ctx, 5*time.Second); err != nil {
+			rescue()
+			return err
+		}
 	}
 	if !podGone {
 		rescue()
@@ -572,7 +611,10 @@ echo "=== Done! ==="
 
 	// Clear stale recovery pods
 	g.deleteRecoveryPods(ctx)
-	time.Sleep(2 * time.Second)
+	if err := engine.Sleep(ctx, 2*time.Second); err != nil {
+		rescue()
+		return err
+	}
 
 	// Scale back up — pods come back in order, find existing cluster, join via SST/IST
 	if err := g.scaleStatefulSet(ctx, originalReplicas); err != nil {
@@ -587,6 +629,7 @@ echo "=== Done! ==="
 		return fmt.Errorf("failed to resume CR: %w", err)
 	}
 	suspended = false
+	g.crSuspended = false
 
 	// Wait for pod to come back online
 	common.InfoLog("Waiting for %s to come back online", targetPod)
@@ -597,7 +640,9 @@ echo "=== Done! ==="
 
 	ready := false
 	for i := 0; i < healTimeout/10; i++ {
-		time.Sleep(10 * time.Second)
+		if err := engine.Sleep(ctx, 10*time.Second); err != nil {
+			return fmt.Errorf("interrupted waiting for %s to come back online: %w", targetPod, err)
+		}
 		pod, err := c.Clientset.CoreV1().Pods(ns).Get(ctx, targetPod, metav1.GetOptions{})
 		if err == nil && pod.Status.Phase == "Running" &&
 			len(pod.Status.ContainerStatuses) > 0 && pod.Status.ContainerStatuses[0].Ready {
@@ -625,7 +670,9 @@ echo "=== Done! ==="
 			break
 		}
 		if i < 11 {
-			time.Sleep(5 * time.Second)
+			if err := engine.Sleep(ctx, 5*time.Second); err != nil {
+				return fmt.Errorf("interrupted verifying Galera join of %s: %w", targetPod, err)
+			}
 		}
 	}
 	if !galeraJoined {
@@ -693,7 +740,9 @@ func (g *galeraRepair) waitForPodGone(ctx context.Context, podName string) error
 			// Transient API error — keep retrying
 			common.DebugLog("waitForPodGone(%s): transient error: %v", podName, err)
 		}
-		time.Sleep(2 * time.Second)
+		if err := engine.Sleep(ctx, 2*time.Second); err != nil {
+			return err
+		}
 	}
 	return fmt.Errorf("pod %s did not terminate within 60s — PVC may still be attached", podName)
 }
@@ -728,7 +777,9 @@ func (g *galeraRepair) runHelperWithRetry(ctx context.Context, name, pvc, mountP
 		if attempt < 3 {
 			common.WarnLog("Helper pod %s mount failed (attempt %d/3): %v", name, attempt, err)
 			common.WarnLog("Retrying (PVC detach lag)...")
-			time.Sleep(time.Duration(attempt * 10) * time.Second)
+			if err := engine.Sleep(ctx, time.Duration(attempt*10)*time.Second); err != nil {
+				return err
+			}
 			continue
 		}
 	}
@@ -781,7 +832,9 @@ func (g *galeraRepair) runHelperPod(ctx context.Context, name, pvcName, mountPat
 
 	// Wait for completion
 	for i := 0; i < 30; i++ {
-		time.Sleep(5 * time.Second)
+		if err := engine.Sleep(ctx, 5*time.Second); err != nil {
+			return fmt.Errorf("helper pod %s: %w", name, err)
+		}
 		p, pErr := c.Clientset.CoreV1().Pods(ns).Get(ctx, name, metav1.GetOptions{})
 		if pErr != nil {
 			continue
@@ -903,7 +956,9 @@ func (g *galeraRepair) waitForAllReady(ctx context.Context) {
 			}
 			common.DebugLog("Ready: %d/%d", ready, expected)
 		}
-		time.Sleep(10 * time.Second)
+		if engine.Sleep(ctx, 10*time.Second) != nil {
+			return
+		}
 	}
 	common.WarnLog("Not all pods became ready within timeout")
 }
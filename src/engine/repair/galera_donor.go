@@ -13,6 +13,7 @@ import (
 	"github.com/PrPlanIT/HASteward/src/output/model"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -59,17 +60,20 @@ func (g *galeraRepair) resolveExplicitDonor(ctx context.Context, ordinal int, re
 	// Structural validation: ordinal in range
 	replicas := int(g.p.Replicas())
 	if ordinal >= replicas {
-		return nil, fmt.Errorf("ABORT: donor ordinal %d is out of range (cluster has instances 0–%d)", ordinal, replicas-1)
+		return nil, refuse(fmt.Errorf("ABORT: donor ordinal %d is out of range (cluster has instances 0–%d)", ordinal, replicas-1))
 	}
 
 	// Structural validation: pod exists and is running
 	c := k8s.GetClients()
 	pod, err := c.Clientset.CoreV1().Pods(cfg.Namespace).Get(ctx, donorPod, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, refuse(fmt.Errorf("ABORT: declared donor %s not found: %w", donorPod, err))
+	}
 	if err != nil {
-		return nil, fmt.Errorf("ABORT: declared donor %s not found: %w", donorPod, err)
+		return nil, fmt.Errorf("failed to get declared donor %s: %w", donorPod, err)
 	}
 	if pod.Status.Phase != corev1.PodRunning {
-		return nil, fmt.Errorf("ABORT: declared donor %s is not Running (phase: %s)", donorPod, pod.Status.Phase)
+		return nil, refuse(fmt.Errorf("ABORT: declared donor %s is not Running (phase: %s)", donorPod, pod.Status.Phase))
 	}
 
 	// Suitability probe: Galera-specific wsrep check
@@ -77,14 +81,14 @@ func (g *galeraRepair) resolveExplicitDonor(ctx context.Context, ordinal int, re
 
 	// No bypass — donor must be verifiably suitable. Explicit intent ≠ valid donor.
 	if !probe.ExecOK {
-		return nil, fmt.Errorf("ABORT: Donor %s probe failed — cannot verify Galera suitability. "+
-			"Ensure the donor pod is running and the mariadb container is accessible", donorPod)
+		return nil, refuse(fmt.Errorf("ABORT: Donor %s probe failed — cannot verify Galera suitability. "+
+			"Ensure the donor pod is running and the mariadb container is accessible", donorPod))
 	}
 	if probe.WsrepReady == nil || !*probe.WsrepReady ||
 		probe.WsrepConnected == nil || !*probe.WsrepConnected ||
 		probe.StateComment != "Synced" {
-		return nil, fmt.Errorf("ABORT: Donor %s is not Galera-suitable "+
-			"(ready=%v connected=%v state=%s)", donorPod, probe.WsrepReady, probe.WsrepConnected, probe.StateComment)
+		return nil, refuse(fmt.Errorf("ABORT: Donor %s is not Galera-suitable "+
+			"(ready=%v connected=%v state=%s)", donorPod, probe.WsrepReady, probe.WsrepConnected, probe.StateComment))
 	}
 
 	common.InfoLog("Using operator-declared donor %s: wsrep_ready=ON, state=Synced — valid donor.", donorPod)
@@ -117,21 +121,21 @@ func (g *galeraRepair) resolveAutoDonor(ctx context.Context, result *model.Triag
 	// No candidates at all
 	if len(candidates) == 0 {
 		if ambiguous {
-			return nil, fmt.Errorf("ABORT: No healthy donor found and authority is ambiguous (divergent UUIDs/split-brain). " +
-				"Use `--force --donor <ordinal>` to declare the authoritative source node")
+			return nil, refuse(fmt.Errorf("ABORT: No healthy donor found and authority is ambiguous (divergent UUIDs/split-brain). " +
+				"Use `--force --donor <ordinal>` to declare the authoritative source node"))
 		}
-		return nil, fmt.Errorf("ABORT: No healthy donor nodes found. " +
-			"All nodes are down or unhealthy. Cannot heal without a running donor to provide SST")
+		return nil, refuse(fmt.Errorf("ABORT: No healthy donor nodes found. " +
+			"All nodes are down or unhealthy. Cannot heal without a running donor to provide SST"))
 	}
 
 	// Ambiguous authority: refuse auto-selection even with candidates
 	if ambiguous {
 		if cfg.Force {
-			return nil, fmt.Errorf("ABORT: Authority is ambiguous (divergent UUIDs/split-brain) — cannot auto-select donor. " +
-				"Use `--force --donor <ordinal>` to declare the authoritative source node")
+			return nil, refuse(fmt.Errorf("ABORT: Authority is ambiguous (divergent UUIDs/split-brain) — cannot auto-select donor. " +
+				"Use `--force --donor <ordinal>` to declare the authoritative source node"))
 		}
-		return nil, fmt.Errorf("ABORT: Authority is ambiguous (divergent UUIDs/split-brain). " +
-			"Review triage output and use `--force --donor <ordinal>` to declare the authoritative source node")
+		return nil, refuse(fmt.Errorf("ABORT: Authority is ambiguous (divergent UUIDs/split-brain). " +
+			"Review triage output and use `--force --donor <ordinal>` to declare the authoritative source node"))
 	}
 
 	// Unambiguous: probe the first candidate via wsrep to confirm suitability
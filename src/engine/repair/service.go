@@ -23,9 +23,20 @@ func Run(ctx context.Context, r Repairer, sink engine.StepSink) (*model.RepairRe
 	result.Cluster = triage.Cluster
 	sink.Step("assess", "done")
 
+	// From SafetyGate on the repairer may hold cluster state (a suspended
+	// CR) that must not outlive a failed or interrupted run.
+	abort := func() {
+		if a, ok := r.(Aborter); ok {
+			cctx, cancel := engine.CleanupContext(ctx)
+			defer cancel()
+			a.Abort(cctx)
+		}
+	}
+
 	// Phase 2: Safety gate
 	sink.Step("safety-gate", "running")
 	if err := r.SafetyGate(ctx, triage); err != nil {
+		abort()
 		return nil, err
 	}
 	sink.Step("safety-gate", "done")
@@ -33,6 +44,7 @@ func Run(ctx context.Context, r Repairer, sink engine.StepSink) (*model.RepairRe
 	// Phase 3: Escrow
 	sink.Step("escrow", "running")
 	if err := r.Escrow(ctx, triage); err != nil {
+		abort()
 		return nil, err
 	}
 	sink.Step("escrow", "done")
@@ -41,11 +53,13 @@ func Run(ctx context.Context, r Repairer, sink engine.StepSink) (*model.RepairRe
 	sink.Step("plan", "running")
 	targets, err := r.PlanTargets(ctx, triage)
 	if err != nil {
+		abort()
 		return nil, err
 	}
 	sink.Step("plan", "done")
 
 	if len(targets) == 0 {
+		abort()
 		result.Duration = time.Since(start)
 		return result, nil
 	}
@@ -54,6 +68,7 @@ func Run(ctx context.Context, r Repairer, sink engine.StepSink) (*model.RepairRe
 	for _, t := range targets {
 		sink.Step("heal-"+t.Pod, "running")
 		if err := r.Heal(ctx, t); err != nil {
+			abort()
 			return nil, fmt.Errorf("heal failed for %s: %w", t.Pod, err)
 		}
 		result.HealedInstances = append(result.HealedInstances, t.Pod)
@@ -8,7 +8,6 @@ import (
 	"os"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/PrPlanIT/HASteward/src/common"
 	"github.com/PrPlanIT/HASteward/src/engine/provider"
@@ -36,30 +35,68 @@ func init() {
 
 type cnpgRestore struct {
 	p *provider.CNPGProvider
+
+	primary  string
+	replicas []string // fenced for the duration of the restore
 }
 
 func (r *cnpgRestore) Name() string { return r.p.Name() }
 
-func (r *cnpgRestore) Restore(ctx context.Context) (*model.RestoreResult, error) {
+// Fence checks the primary is ready and fences every replica so none of them
+// replicates a partially restored database.
+func (r *cnpgRestore) Fence(ctx context.Context) error {
 	cfg := r.p.Config()
 	if cfg.BackupMethod == "native" {
-		return nil, fmt.Errorf("native S3 restore (PITR via bootstrap.recovery) is not yet implemented. Use --method dump")
+		return fmt.Errorf("native S3 restore (PITR via bootstrap.recovery) is not yet implemented. Use --method dump")
+	}
+	ns := cfg.Namespace
+	r.primary = k8s.GetNestedString(r.p.Cluster(), "status", "currentPrimary")
+
+	// Verify primary is running and ready
+	c := k8s.GetClients()
+	pod, err := c.Clientset.CoreV1().Pods(ns).Get(ctx, r.primary, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("primary pod %s not found: %w", r.primary, err)
+	}
+	if pod.Status.Phase != "Running" || len(pod.Status.ContainerStatuses) == 0 || !pod.Status.ContainerStatuses[0].Ready {
+		return fmt.Errorf("primary pod %s is not running and ready", r.primary)
+	}
+
+	// Get replica instance names (non-primary)
+	r.replicas = nil
+	if names := k8s.GetNestedSlice(r.p.Cluster(), "status", "instanceNames"); names != nil {
+		for _, n := range names {
+			if s, ok := n.(string); ok && s != r.primary {
+				r.replicas = append(r.replicas, s)
+			}
+		}
+	}
+
+	// Fence replicas before restore
+	if len(r.replicas) > 0 {
+		common.InfoLog("Fencing replicas: %s", strings.Join(r.replicas, ", "))
+		fencedJSON, _ := json.Marshal(r.replicas)
+		patch := fmt.Sprintf(`{"metadata":{"annotations":{"cnpg.io/fencedInstances":%q}}}`, string(fencedJSON))
+		_, err := c.Dynamic.Resource(k8s.CNPGClusterGVR).Namespace(ns).Patch(
+			ctx, cfg.ClusterName, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to fence replicas: %w", err)
+		}
 	}
-	return r.restoreDump(ctx)
+	return nil
 }
 
-func (r *cnpgRestore) restoreDump(ctx context.Context) (*model.RestoreResult, error) {
-	start := time.Now()
+// Stream pipes restic dump into psql on the primary.
+func (r *cnpgRestore) Stream(ctx context.Context) (*model.RestoreResult, error) {
 	cfg := r.p.Config()
 	ns := cfg.Namespace
-	primary := k8s.GetNestedString(r.p.Cluster(), "status", "currentPrimary")
 
 	snapshotID := cfg.Snapshot
 	if snapshotID == "" {
 		snapshotID = "latest"
 	}
 
-	rc := restic.NewClient(cfg.BackupsPath, cfg.ResticPassword)
+	rc := restic.NewClient(cfg.BackupsPath, cfg.ResticPassword, cfg.ResticEnv)
 	// Diverged snapshots use ordinal-prefixed filename
 	dumpFile := DumpFilenameCNPG
 	if cfg.InstanceNumber != nil {
@@ -74,43 +111,12 @@ func (r *cnpgRestore) restoreDump(ctx context.Context) (*model.RestoreResult, er
 
 	output.Section("Dump Restore")
 	output.Field("Snapshot", snapshotID)
-	output.Field("Primary", primary)
+	output.Field("Primary", r.primary)
 	output.Field("Repository", cfg.BackupsPath)
 
-	// Verify primary is running and ready
-	c := k8s.GetClients()
-	pod, err := c.Clientset.CoreV1().Pods(ns).Get(ctx, primary, metav1.GetOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("primary pod %s not found: %w", primary, err)
-	}
-	if pod.Status.Phase != "Running" || len(pod.Status.ContainerStatuses) == 0 || !pod.Status.ContainerStatuses[0].Ready {
-		return nil, fmt.Errorf("primary pod %s is not running and ready", primary)
-	}
-
-	// Get replica instance names (non-primary)
-	var replicas []string
-	if names := k8s.GetNestedSlice(r.p.Cluster(), "status", "instanceNames"); names != nil {
-		for _, n := range names {
-			if s, ok := n.(string); ok && s != primary {
-				replicas = append(replicas, s)
-			}
-		}
-	}
-
-	// Fence replicas before restore
-	if len(replicas) > 0 {
-		common.InfoLog("Fencing replicas: %s", strings.Join(replicas, ", "))
-		fencedJSON, _ := json.Marshal(replicas)
-		patch := fmt.Sprintf(`{"metadata":{"annotations":{"cnpg.io/fencedInstances":%q}}}`, string(fencedJSON))
-		_, err := c.Dynamic.Resource(k8s.CNPGClusterGVR).Namespace(ns).Patch(
-			ctx, cfg.ClusterName, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
-		if err != nil {
-			return nil, fmt.Errorf("failed to fence replicas: %w", err)
-		}
-	}
-
 	// Set up pipe: restic dump -> pipe -> psql stdin
 	pr, pw := io.Pipe()
+	counter := &countingReader{r: pr}
 
 	var resticErr error
 	done := make(chan struct{})
@@ -124,45 +130,54 @@ func (r *cnpgRestore) restoreDump(ctx context.Context) (*model.RestoreResult, er
 	}()
 
 	common.InfoLog("Streaming restic dump → psql")
-	err = k8s.ExecStream(ctx, primary, ns, "postgres",
+	err := k8s.ExecStream(ctx, r.primary, ns, "postgres",
 		[]string{"psql", "-U", "postgres"},
-		pr, output.Writer(), os.Stderr)
+		counter, output.Writer(), os.Stderr)
 	<-done
 
 	if err != nil {
-		r.unfenceAll(ctx, ns)
 		return nil, fmt.Errorf("restore stream failed: %w", err)
 	}
 	if resticErr != nil {
-		r.unfenceAll(ctx, ns)
 		return nil, fmt.Errorf("restic dump failed: %w", resticErr)
 	}
 
 	output.Section("Restore Complete")
+	output.Field("Restored", output.FormatBytes(counter.n.Load()))
+	return &model.RestoreResult{
+		Engine:        r.p.Name(),
+		Cluster:       model.ObjectRef{Namespace: ns, Name: cfg.ClusterName},
+		SnapshotID:    snapshotID,
+		BytesRestored: counter.n.Load(),
+	}, nil
+}
 
-	// Unfence replicas
-	if len(replicas) > 0 {
-		r.unfenceAll(ctx, ns)
-
-		// Delete replica pods to force clean re-sync
-		for _, replica := range replicas {
-			_ = c.Clientset.CoreV1().Pods(ns).Delete(ctx, replica, metav1.DeleteOptions{
-				GracePeriodSeconds: ptr(int64(0)),
-			})
+// Unfence lifts replica fencing. After a successful restore the replica pods
+// are also deleted so they re-sync from the restored primary.
+func (r *cnpgRestore) Unfence(ctx context.Context, restored bool) error {
+	ns := r.p.Config().Namespace
+	if len(r.replicas) > 0 {
+		if err := r.unfenceAll(ctx, ns); err != nil {
+			return err
+		}
+		if restored {
+			// Delete replica pods to force clean re-sync
+			c := k8s.GetClients()
+			for _, replica := range r.replicas {
+				_ = c.Clientset.CoreV1().Pods(ns).Delete(ctx, replica, metav1.DeleteOptions{
+					GracePeriodSeconds: ptr(int64(0)),
+				})
+			}
+			common.InfoLog("Replicas unfenced and deleted — they will re-sync from primary via streaming replication")
 		}
-		common.InfoLog("Replicas unfenced and deleted — they will re-sync from primary via streaming replication")
 	}
-
-	output.Success("Restore complete")
-	return &model.RestoreResult{
-		Engine:     r.p.Name(),
-		Cluster:    model.ObjectRef{Namespace: ns, Name: cfg.ClusterName},
-		SnapshotID: snapshotID,
-		Duration:   time.Since(start),
-	}, nil
+	if restored {
+		output.Success("Restore complete")
+	}
+	return nil
 }
 
-func (r *cnpgRestore) unfenceAll(ctx context.Context, ns string) {
+func (r *cnpgRestore) unfenceAll(ctx context.Context, ns string) error {
 	cfg := r.p.Config()
 	c := k8s.GetClients()
 	patch := `{"metadata":{"annotations":{"cnpg.io/fencedInstances":"[]"}}}`
@@ -170,7 +185,9 @@ func (r *cnpgRestore) unfenceAll(ctx context.Context, ns string) {
 		ctx, cfg.ClusterName, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
 	if err != nil {
 		common.WarnLog("Failed to unfence replicas: %v", err)
+		return fmt.Errorf("failed to unfence replicas: %w", err)
 	}
+	return nil
 }
 
 // ptr returns a pointer to the given value.
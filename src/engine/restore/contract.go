@@ -9,5 +9,12 @@ import (
 // Restorer is the engine-specific hook contract for restore operations.
 type Restorer interface {
 	Name() string
-	Restore(ctx context.Context) (*model.RestoreResult, error)
+	// Fence prepares the cluster and isolates instances that must not
+	// replicate a half-restored database.
+	Fence(ctx context.Context) error
+	// Stream pipes the snapshot dump into the database.
+	Stream(ctx context.Context) (*model.RestoreResult, error)
+	// Unfence lifts fencing. It is called after Stream whether or not the
+	// stream succeeded; restored reports which.
+	Unfence(ctx context.Context, restored bool) error
 }
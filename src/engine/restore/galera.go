@@ -6,7 +6,6 @@ import (
 	"io"
 	"os"
 	"strconv"
-	"time"
 
 	"github.com/PrPlanIT/HASteward/src/common"
 	"github.com/PrPlanIT/HASteward/src/engine/provider"
@@ -33,31 +32,34 @@ func init() {
 
 type galeraRestore struct {
 	p *provider.GaleraProvider
+
+	target string // node the dump is streamed into
 }
 
 func (r *galeraRestore) Name() string { return r.p.Name() }
 
-func (r *galeraRestore) Restore(ctx context.Context) (*model.RestoreResult, error) {
-	return r.restoreDump(ctx)
+// Fence selects a healthy node to restore into. Galera replication carries the
+// restore to the other nodes, so nothing is fenced.
+func (r *galeraRestore) Fence(ctx context.Context) error {
+	target, err := r.findHealthyPod(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot find healthy pod for restore: %w", err)
+	}
+	r.target = target
+	return nil
 }
 
-func (r *galeraRestore) restoreDump(ctx context.Context) (*model.RestoreResult, error) {
-	start := time.Now()
+// Stream pipes restic dump into mysql on the target node.
+func (r *galeraRestore) Stream(ctx context.Context) (*model.RestoreResult, error) {
 	cfg := r.p.Config()
 	ns := cfg.Namespace
 
-	// Find a healthy target pod
-	target, err := r.findHealthyPod(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("cannot find healthy pod for restore: %w", err)
-	}
-
 	snapshotID := cfg.Snapshot
 	if snapshotID == "" {
 		snapshotID = "latest"
 	}
 
-	rc := restic.NewClient(cfg.BackupsPath, cfg.ResticPassword)
+	rc := restic.NewClient(cfg.BackupsPath, cfg.ResticPassword, cfg.ResticEnv)
 	// Diverged snapshots use ordinal-prefixed filename
 	dumpFile := DumpFilenameGalera
 	if cfg.InstanceNumber != nil {
@@ -72,11 +74,12 @@ func (r *galeraRestore) restoreDump(ctx context.Context) (*model.RestoreResult,
 
 	output.Section("Dump Restore")
 	output.Field("Snapshot", snapshotID)
-	output.Field("Target", target)
+	output.Field("Target", r.target)
 	output.Field("Repository", cfg.BackupsPath)
 
 	// Set up pipe: restic dump -> pipe -> mysql stdin
 	pr, pw := io.Pipe()
+	counter := &countingReader{r: pr}
 
 	var resticErr error
 	done := make(chan struct{})
@@ -95,7 +98,7 @@ func (r *galeraRestore) restoreDump(ctx context.Context) (*model.RestoreResult,
 			"mysql -u root"}
 
 	common.InfoLog("Streaming restic dump → mysql")
-	err = k8s.ExecStream(ctx, target, ns, "mariadb", cmd, pr, output.Writer(), os.Stderr)
+	err := k8s.ExecStream(ctx, r.target, ns, "mariadb", cmd, counter, output.Writer(), os.Stderr)
 	<-done
 
 	if err != nil {
@@ -106,16 +109,20 @@ func (r *galeraRestore) restoreDump(ctx context.Context) (*model.RestoreResult,
 	}
 
 	output.Section("Restore Complete")
+	output.Field("Restored", output.FormatBytes(counter.n.Load()))
 	common.InfoLog("Galera replication will propagate the restored data to other nodes")
 	output.Success("Restore complete")
 	return &model.RestoreResult{
-		Engine:     r.p.Name(),
-		Cluster:    model.ObjectRef{Namespace: ns, Name: cfg.ClusterName},
-		SnapshotID: snapshotID,
-		Duration:   time.Since(start),
+		Engine:        r.p.Name(),
+		Cluster:       model.ObjectRef{Namespace: ns, Name: cfg.ClusterName},
+		SnapshotID:    snapshotID,
+		BytesRestored: counter.n.Load(),
 	}, nil
 }
 
+// Unfence is a no-op: Fence does not fence any node.
+func (r *galeraRestore) Unfence(context.Context, bool) error { return nil }
+
 // findHealthyPod returns the name of a healthy running MariaDB pod.
 func (r *galeraRestore) findHealthyPod(ctx context.Context) (string, error) {
 	cfg := r.p.Config()
@@ -2,6 +2,10 @@ package restore
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
 
 	"github.com/PrPlanIT/HASteward/src/engine"
 	"github.com/PrPlanIT/HASteward/src/output/model"
@@ -9,11 +13,46 @@ import (
 
 // Run is the shared restore lifecycle.
 func Run(ctx context.Context, r Restorer, sink engine.StepSink) (*model.RestoreResult, error) {
-	sink.Step("restore", "running")
-	result, err := r.Restore(ctx)
-	if err != nil {
+	start := time.Now()
+
+	sink.Step("fencing", "running")
+	if err := r.Fence(ctx); err != nil {
 		return nil, err
 	}
-	sink.Step("restore", "done")
+	sink.Step("fencing", "done")
+
+	sink.Step("streaming", "running")
+	result, streamErr := r.Stream(ctx)
+	if streamErr == nil {
+		sink.Step("streaming", "done")
+	}
+
+	// Always unfence, even after a failed or cancelled stream
+	sink.Step("unfencing", "running")
+	cctx, cancel := engine.CleanupContext(ctx)
+	defer cancel()
+	unfenceErr := r.Unfence(cctx, streamErr == nil)
+	if streamErr != nil {
+		return nil, streamErr
+	}
+	if unfenceErr != nil {
+		return nil, fmt.Errorf("restore completed but unfencing failed: %w", unfenceErr)
+	}
+	sink.Step("unfencing", "done")
+
+	result.Duration = time.Since(start)
 	return result, nil
 }
+
+// countingReader counts the bytes streamed into the database. Reads happen on
+// the exec goroutine, so the count is atomic.
+type countingReader struct {
+	r io.Reader
+	n atomic.Int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n.Add(int64(n))
+	return n, err
+}
@@ -28,7 +28,7 @@ func (r *cnpgRetainer) Name() string { return "cnpg" }
 
 func (r *cnpgRetainer) Prune(ctx context.Context, opts PruneOptions) (*model.PruneResult, error) {
 	cfg := r.p.Config()
-	rc := restic.NewClient(cfg.BackupsPath, cfg.ResticPassword)
+	rc := restic.NewClient(cfg.BackupsPath, cfg.ResticPassword, cfg.ResticEnv)
 
 	baseTags := map[string]string{
 		"engine":    "cnpg",
@@ -28,7 +28,7 @@ func (r *galeraRetainer) Name() string { return "galera" }
 
 func (r *galeraRetainer) Prune(ctx context.Context, opts PruneOptions) (*model.PruneResult, error) {
 	cfg := r.p.Config()
-	rc := restic.NewClient(cfg.BackupsPath, cfg.ResticPassword)
+	rc := restic.NewClient(cfg.BackupsPath, cfg.ResticPassword, cfg.ResticEnv)
 
 	baseTags := map[string]string{
 		"engine":    "galera",
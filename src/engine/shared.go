@@ -1,8 +1,10 @@
 package engine
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"time"
 )
 
 // ErrNotSupported indicates an operation is not available for a given engine.
@@ -23,3 +25,26 @@ type StepSink interface {
 type NopSink struct{}
 
 func (NopSink) Step(string, string) {}
+
+// CleanupTimeout bounds rescue work (unfencing, resuming a suspended CR,
+// deleting helper pods) after an operation failed or was interrupted.
+const CleanupTimeout = 30 * time.Second
+
+// CleanupContext returns a context for rescue work that survives the
+// cancellation of ctx, so an interrupted operation still undoes its changes.
+// It keeps ctx's values and expires after CleanupTimeout.
+func CleanupContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), CleanupTimeout)
+}
+
+// Sleep waits for d, returning ctx's error early if ctx is cancelled first.
+func Sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
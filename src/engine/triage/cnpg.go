@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/PrPlanIT/HASteward/src/common"
+	"github.com/PrPlanIT/HASteward/src/engine"
 	"github.com/PrPlanIT/HASteward/src/engine/provider"
 	"github.com/PrPlanIT/HASteward/src/k8s"
 	"github.com/PrPlanIT/HASteward/src/output"
@@ -483,9 +484,11 @@ func (t *cnpgTriage) runPVCProbes(ctx context.Context, targets []cnpgProbeTarget
 		results[tgt.Name] = cd
 
 		// Cleanup
-		_ = c.Clientset.CoreV1().Pods(ns).Delete(ctx, probeName, metav1.DeleteOptions{
+		cctx, cancel := engine.CleanupContext(ctx)
+		_ = c.Clientset.CoreV1().Pods(ns).Delete(cctx, probeName, metav1.DeleteOptions{
 			GracePeriodSeconds: ptr(int64(0)),
 		})
+		cancel()
 	}
 
 	return results
@@ -614,8 +617,8 @@ func cnpgCrossInstanceComparison(data *cnpgTriageData, primaryName string) model
 			splitBrain = append(splitBrain,
 				fmt.Sprintf("%s has timeline %s > primary timeline %d", inst.Pod, inst.Timeline, pTL))
 		} else if instTL == mostAdvancedTL && inst.CheckpointLocation != "unknown" && pLSN != "unknown" {
-			instLSNVal := parseLSNValue(inst.CheckpointLocation)
-			pLSNVal := parseLSNValue(pLSN)
+			instLSNVal := ParseLSN(inst.CheckpointLocation)
+			pLSNVal := ParseLSN(pLSN)
 			if instLSNVal > pLSNVal {
 				splitBrain = append(splitBrain,
 					fmt.Sprintf("%s LSN %s ahead of primary %s on same timeline",
@@ -653,7 +656,7 @@ func (t *cnpgTriage) buildAssessments(data *cnpgTriageData, comparison *model.Da
 	if data.primaryControlData != nil {
 		pLSN = strings.TrimSpace(data.primaryControlData.CheckpointLocation)
 	}
-	pLSNVal := parseLSNValue(pLSN)
+	pLSNVal := ParseLSN(pLSN)
 
 	missingSet := setFromSlice(data.missingInstances)
 	crashloopSet := podNameSet(data.crashloopPods)
@@ -671,7 +674,7 @@ func (t *cnpgTriage) buildAssessments(data *cnpgTriageData, comparison *model.Da
 		hasData := inst.Source != "none"
 		instTL := strings.TrimSpace(inst.Timeline)
 		instLSN := strings.TrimSpace(inst.CheckpointLocation)
-		instLSNVal := parseLSNValue(instLSN)
+		instLSNVal := ParseLSN(instLSN)
 
 		sameTL := instTL == pTL && instTL != "unknown"
 		behindTL := instTL != "unknown" && pTL != "unknown" && parseTimelineInt(instTL) < parseTimelineInt(pTL)
@@ -940,7 +943,9 @@ func parseControlData(podName, source, raw string) controlData {
 	return cd
 }
 
-func parseLSNValue(lsn string) int64 {
+// ParseLSN converts a PostgreSQL LSN ("16/B374D848") to a byte position.
+// Unknown or malformed values are 0.
+func ParseLSN(lsn string) int64 {
 	if lsn == "" || lsn == "unknown" {
 		return 0
 	}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/PrPlanIT/HASteward/src/common"
+	"github.com/PrPlanIT/HASteward/src/engine"
 	"github.com/PrPlanIT/HASteward/src/engine/provider"
 	"github.com/PrPlanIT/HASteward/src/k8s"
 	"github.com/PrPlanIT/HASteward/src/output"
@@ -455,9 +456,11 @@ func (t *galeraTriage) runPVCProbes(ctx context.Context, targets []galeraProbeTa
 		}
 
 		// Cleanup
-		_ = c.Clientset.CoreV1().Pods(ns).Delete(ctx, probeName, metav1.DeleteOptions{
+		cctx, cancel := engine.CleanupContext(ctx)
+		_ = c.Clientset.CoreV1().Pods(ns).Delete(cctx, probeName, metav1.DeleteOptions{
 			GracePeriodSeconds: ptr(int64(0)),
 		})
+		cancel()
 	}
 	return results
 }
@@ -0,0 +1,130 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/PrPlanIT/HASteward/src/common"
+	"github.com/PrPlanIT/HASteward/src/k8s"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+)
+
+// Event reasons. These are stable codes that alert rules and
+// `kubectl get events --field-selector reason=...` match on; do not rename.
+const (
+	ReasonBackupSucceeded    = "BackupSucceeded"
+	ReasonBackupFailed       = "BackupFailed"
+	ReasonSplitBrainDetected = "SplitBrainDetected"
+	ReasonRepairStarted      = "RepairStarted"
+	ReasonRepairSucceeded    = "RepairSucceeded"
+	ReasonRepairFailed       = "RepairFailed"
+	ReasonRepairRefused      = "RepairRefused"
+	ReasonBootstrapSucceeded = "BootstrapSucceeded"
+	ReasonBootstrapFailed    = "BootstrapFailed"
+	ReasonRestoreSucceeded   = "RestoreSucceeded"
+	ReasonRestoreFailed      = "RestoreFailed"
+	ReasonRepairProposed     = "RepairProposed"
+	ReasonPolicyConflict     = "PolicyConflict"
+	ReasonPolicyNotFound     = "PolicyNotFound"
+	ReasonRepositoryNotFound = "RepositoryNotFound"
+	ReasonRepairCircuitOpen  = "RepairCircuitOpen"
+	ReasonRepairCircuitReset = "RepairCircuitReset"
+)
+
+// Component is the event source reported on every Event.
+const Component = "hasteward"
+
+// maxMessageLength keeps messages within the events.k8s.io note limit.
+const maxMessageLength = 1024
+
+// Record creates an Event on the CNPG Cluster or MariaDB CR identified by
+// engine, namespace and name. eventType is corev1.EventTypeNormal or
+// corev1.EventTypeWarning. Events are best effort: failures are logged at
+// warn level and never returned.
+//
+// Every call creates a new Event, which suits the one-shot CLI. Long-running
+// callers use RecordWith so repeated events are aggregated.
+func Record(ctx context.Context, engine, namespace, name, eventType, reason, format string, args ...any) {
+	obj, ok := target(ctx, engine, namespace, name, reason)
+	if !ok {
+		return
+	}
+	message := truncate(fmt.Sprintf(format, args...))
+
+	now := metav1.Now()
+	ev := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: name + ".",
+			Namespace:    namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion:      obj.GetAPIVersion(),
+			Kind:            obj.GetKind(),
+			Namespace:       namespace,
+			Name:            name,
+			UID:             obj.GetUID(),
+			ResourceVersion: obj.GetResourceVersion(),
+		},
+		Reason:         reason,
+		Message:        message,
+		Type:           eventType,
+		Source:         corev1.EventSource{Component: Component},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	c := k8s.GetClients()
+	if _, err := c.Clientset.CoreV1().Events(namespace).Create(ctx, ev, metav1.CreateOptions{}); err != nil {
+		common.WarnLog("Failed to record %s event on %s/%s: %v", reason, namespace, name, err)
+	}
+}
+
+// RecordWith is Record through recorder, whose correlator aggregates repeated
+// events into one with a count and rate-limits floods. The operator passes
+// the manager's recorder.
+func RecordWith(ctx context.Context, recorder record.EventRecorder, engine, namespace, name, eventType, reason, format string, args ...any) {
+	obj, ok := target(ctx, engine, namespace, name, reason)
+	if !ok {
+		return
+	}
+	recorder.Event(obj, eventType, reason, truncate(fmt.Sprintf(format, args...)))
+}
+
+// target fetches the database CR an event is recorded on.
+func target(ctx context.Context, engine, namespace, name, reason string) (*unstructured.Unstructured, bool) {
+	var gvr schema.GroupVersionResource
+	switch engine {
+	case "cnpg":
+		gvr = k8s.CNPGClusterGVR
+	case "galera":
+		gvr = k8s.MariaDBGVR
+	default:
+		return nil, false
+	}
+
+	obj, err := k8s.GetClients().Dynamic.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		common.WarnLog("Failed to record %s event on %s/%s: %v", reason, namespace, name, err)
+		return nil, false
+	}
+	return obj, true
+}
+
+// truncate shortens message to maxMessageLength bytes, cutting on a rune
+// boundary so the result stays valid UTF-8.
+func truncate(message string) string {
+	if len(message) <= maxMessageLength {
+		return message
+	}
+	cut := maxMessageLength - 3
+	for cut > 0 && !utf8.RuneStart(message[cut]) {
+		cut--
+	}
+	return message[:cut] + "..."
+}
@@ -37,6 +37,18 @@ var (
 		Name:      "backup_size_bytes",
 		Help:      "Size of the last backup in bytes.",
 	}, []string{"engine", "cluster", "namespace", "repository"})
+
+	BackupAgeSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "backup_age_seconds",
+		Help:      "Seconds since the last successful backup (since management began if there is none).",
+	}, []string{"engine", "cluster", "namespace", "repository"})
+
+	BackupRPOViolated = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "backup_rpo_violated",
+		Help:      "1 if the last successful backup is older than the database's RPO.",
+	}, []string{"engine", "cluster", "namespace", "repository"})
 )
 
 // --- Triage metrics ---
@@ -81,6 +93,62 @@ var (
 		Name:      "repair_last_timestamp",
 		Help:      "Unix timestamp of the last repair.",
 	}, []string{"engine", "cluster", "namespace"})
+
+	RepairCircuitOpen = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "repair_circuit_open",
+		Help:      "Whether auto-repair is stopped by its circuit breaker (1 = open).",
+	}, []string{"engine", "cluster", "namespace"})
+
+	RepairBlockedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "repair_blocked_total",
+		Help:      "Total number of auto-repairs skipped by repair limits, by reason (rate_limit, backoff, circuit_open).",
+	}, []string{"engine", "cluster", "namespace", "reason"})
+)
+
+// --- Restore metrics ---
+
+var (
+	RestoreTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "restore_total",
+		Help:      "Total number of operator-run restores (RestoreRequests).",
+	}, []string{"engine", "cluster", "namespace", "status"})
+
+	RestoreBytesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "restore_bytes_total",
+		Help:      "Bytes streamed into databases by operator-run restores.",
+	}, []string{"engine", "cluster", "namespace"})
+)
+
+// --- Retention metrics ---
+
+var (
+	RetentionTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "retention_total",
+		Help:      "Total number of scheduled retention (prune) operations.",
+	}, []string{"engine", "cluster", "namespace", "repository", "status"})
+
+	RetentionLastSuccessTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "retention_last_success_timestamp",
+		Help:      "Unix timestamp of the last successful retention run.",
+	}, []string{"engine", "cluster", "namespace", "repository"})
+
+	RetentionSnapshotsKept = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "retention_snapshots_kept",
+		Help:      "Number of snapshots kept by the last retention run.",
+	}, []string{"engine", "cluster", "namespace", "repository"})
+
+	RetentionSnapshotsRemovedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "retention_snapshots_removed_total",
+		Help:      "Total number of snapshots removed by retention.",
+	}, []string{"engine", "cluster", "namespace", "repository"})
 )
 
 // --- Repository metrics ---
@@ -103,6 +171,30 @@ var (
 		Name:      "repository_deduplicated_size_bytes",
 		Help:      "Deduplicated size of a backup repository in bytes.",
 	}, []string{"repository"})
+
+	RepositoryCheckTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "repository_check_total",
+		Help:      "Total number of scheduled restic check runs. Labels: status=success|failure|skipped.",
+	}, []string{"repository", "status"})
+
+	RepositoryCheckLastSuccessTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "repository_check_last_success_timestamp",
+		Help:      "Unix timestamp of the last successful restic check.",
+	}, []string{"repository"})
+
+	RepositoryCheckLastDurationSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "repository_check_last_duration_seconds",
+		Help:      "Duration of the last restic check in seconds.",
+	}, []string{"repository"})
+
+	RepositoryCheckHealthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "repository_check_healthy",
+		Help:      "Result of the last restic check (1=passed, 0=failed).",
+	}, []string{"repository"})
 )
 
 // --- Operator health metrics ---
@@ -119,6 +211,57 @@ var (
 		Name:      "controller_reconcile_total",
 		Help:      "Total number of controller reconciliation loops.",
 	}, []string{"engine", "status"})
+
+	PolicyConflicts = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "policy_conflicts",
+		Help:      "Number of policies whose selectors match a database in addition to the one applied.",
+	}, []string{"engine", "cluster", "namespace"})
+)
+
+// --- Notification metrics ---
+
+var (
+	NotificationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "notifications_total",
+		Help:      "Total number of notifications sent, by NotificationChannel and status.",
+	}, []string{"channel", "status"})
+)
+
+// --- Scheduler queue metrics ---
+
+var (
+	QueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "scheduler_queue_depth",
+		Help:      "Number of jobs waiting in a database's scheduler queue.",
+	}, []string{"engine", "cluster", "namespace"})
+
+	QueueWaitSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "scheduler_queue_wait_seconds",
+		Help:      "Time a job waited in its database queue before starting.",
+		Buckets:   []float64{1, 5, 15, 30, 60, 300, 900, 1800, 3600, 7200},
+	}, []string{"engine", "operation"})
+
+	QueueCoalescedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "scheduler_jobs_coalesced_total",
+		Help:      "Triggers dropped because an identical job was already pending.",
+	}, []string{"engine", "cluster", "namespace", "operation"})
+
+	MissedRunsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "scheduler_missed_runs_total",
+		Help:      "Scheduled runs missed while the operator was down, by action (caught_up or skipped).",
+	}, []string{"engine", "cluster", "namespace", "operation", "action"})
+
+	DeferredTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "scheduler_deferred_total",
+		Help:      "Scheduled operations deferred by a blackout window or until a maintenance window opens.",
+	}, []string{"engine", "cluster", "namespace", "operation", "window"})
 )
 
 func init() {
@@ -130,6 +273,8 @@ func init() {
 		BackupLastDurationSeconds,
 		BackupTotal,
 		BackupSizeBytes,
+		BackupAgeSeconds,
+		BackupRPOViolated,
 		// Triage
 		TriageLastRunTimestamp,
 		TriageHealthyInstances,
@@ -138,13 +283,36 @@ func init() {
 		// Repair
 		RepairTotal,
 		RepairLastTimestamp,
+		RepairCircuitOpen,
+		RepairBlockedTotal,
+		// Restore
+		RestoreTotal,
+		RestoreBytesTotal,
+		// Retention
+		RetentionTotal,
+		RetentionLastSuccessTimestamp,
+		RetentionSnapshotsKept,
+		RetentionSnapshotsRemovedTotal,
 		// Repository
 		RepositorySnapshotCount,
 		RepositoryTotalSizeBytes,
 		RepositoryDeduplicatedSizeBytes,
+		RepositoryCheckTotal,
+		RepositoryCheckLastSuccessTimestamp,
+		RepositoryCheckLastDurationSeconds,
+		RepositoryCheckHealthy,
 		// Operator
 		ManagedDatabases,
 		ControllerReconcileTotal,
+		PolicyConflicts,
+		// Scheduler queue
+		QueueDepth,
+		QueueWaitSeconds,
+		QueueCoalescedTotal,
+		MissedRunsTotal,
+		DeferredTotal,
+		// Notifications
+		NotificationsTotal,
 	)
 }
 
@@ -172,6 +340,26 @@ func RecordBackupFailure(engine, cluster, ns, repo string) {
 	}).Inc()
 }
 
+// RecordBackupAge sets the backup staleness gauges for one repository.
+func RecordBackupAge(engine, cluster, ns, repo string, age time.Duration, violated bool) {
+	labels := prometheus.Labels{
+		"engine": engine, "cluster": cluster, "namespace": ns, "repository": repo,
+	}
+	BackupAgeSeconds.With(labels).Set(age.Seconds())
+	v := 0.0
+	if violated {
+		v = 1
+	}
+	BackupRPOViolated.With(labels).Set(v)
+}
+
+// DeleteBackupAge removes the staleness gauges of a deregistered database.
+func DeleteBackupAge(engine, cluster, ns string) {
+	labels := prometheus.Labels{"engine": engine, "cluster": cluster, "namespace": ns}
+	BackupAgeSeconds.DeletePartialMatch(labels)
+	BackupRPOViolated.DeletePartialMatch(labels)
+}
+
 // RecordTriageResult records metrics for a triage operation.
 func RecordTriageResult(engine, cluster, ns string, result *model.TriageResult, triageStatus string) {
 	labels := prometheus.Labels{
@@ -213,6 +401,102 @@ func RecordRepairFailure(engine, cluster, ns string) {
 	}).Set(float64(time.Now().Unix()))
 }
 
+// RecordRepairBlocked counts an auto-repair skipped by repair limits.
+func RecordRepairBlocked(engine, cluster, ns, reason string) {
+	RepairBlockedTotal.With(prometheus.Labels{
+		"engine": engine, "cluster": cluster, "namespace": ns, "reason": reason,
+	}).Inc()
+}
+
+// RecordRepairCircuit sets the auto-repair circuit breaker state.
+func RecordRepairCircuit(engine, cluster, ns string, open bool) {
+	v := 0.0
+	if open {
+		v = 1
+	}
+	RepairCircuitOpen.With(prometheus.Labels{"engine": engine, "cluster": cluster, "namespace": ns}).Set(v)
+}
+
+// DeleteRepairCircuit removes the circuit gauge of a deregistered database.
+func DeleteRepairCircuit(engine, cluster, ns string) {
+	RepairCircuitOpen.Delete(prometheus.Labels{"engine": engine, "cluster": cluster, "namespace": ns})
+}
+
+// RecordRestoreSuccess records metrics for a successful restore.
+func RecordRestoreSuccess(engine, cluster, ns string, bytes int64) {
+	RestoreTotal.With(prometheus.Labels{
+		"engine": engine, "cluster": cluster, "namespace": ns, "status": "success",
+	}).Inc()
+	RestoreBytesTotal.With(prometheus.Labels{
+		"engine": engine, "cluster": cluster, "namespace": ns,
+	}).Add(float64(bytes))
+}
+
+// RecordRestoreFailure records metrics for a failed restore.
+func RecordRestoreFailure(engine, cluster, ns string) {
+	RestoreTotal.With(prometheus.Labels{
+		"engine": engine, "cluster": cluster, "namespace": ns, "status": "failure",
+	}).Inc()
+}
+
+// RecordRetentionSuccess records metrics for a successful retention run.
+func RecordRetentionSuccess(engine, cluster, ns, repo string, result *model.PruneResult) {
+	labels := prometheus.Labels{
+		"engine": engine, "cluster": cluster, "namespace": ns, "repository": repo,
+	}
+	RetentionTotal.With(prometheus.Labels{
+		"engine": engine, "cluster": cluster, "namespace": ns, "repository": repo, "status": "success",
+	}).Inc()
+	RetentionLastSuccessTimestamp.With(labels).Set(float64(time.Now().Unix()))
+	RetentionSnapshotsKept.With(labels).Set(float64(result.TotalKept))
+	RetentionSnapshotsRemovedTotal.With(labels).Add(float64(result.TotalRemoved))
+}
+
+// RecordRetentionFailure records metrics for a failed retention run.
+func RecordRetentionFailure(engine, cluster, ns, repo string) {
+	RetentionTotal.With(prometheus.Labels{
+		"engine": engine, "cluster": cluster, "namespace": ns, "repository": repo, "status": "failure",
+	}).Inc()
+}
+
+// RecordRepositoryStats updates the gauges for a backup repository.
+func RecordRepositoryStats(repo string, snapshots int, totalSize, dedupSize int64) {
+	labels := prometheus.Labels{"repository": repo}
+	RepositorySnapshotCount.With(labels).Set(float64(snapshots))
+	RepositoryTotalSizeBytes.With(labels).Set(float64(totalSize))
+	RepositoryDeduplicatedSizeBytes.With(labels).Set(float64(dedupSize))
+}
+
+// DeleteRepositoryStats removes the gauges for a deleted backup repository.
+func DeleteRepositoryStats(repo string) {
+	labels := prometheus.Labels{"repository": repo}
+	RepositorySnapshotCount.Delete(labels)
+	RepositoryTotalSizeBytes.Delete(labels)
+	RepositoryDeduplicatedSizeBytes.Delete(labels)
+	RepositoryCheckLastSuccessTimestamp.Delete(labels)
+	RepositoryCheckLastDurationSeconds.Delete(labels)
+	RepositoryCheckHealthy.Delete(labels)
+}
+
+// RecordRepositoryCheck records the outcome of a restic check.
+func RecordRepositoryCheck(repo string, duration time.Duration, passed bool) {
+	labels := prometheus.Labels{"repository": repo}
+	RepositoryCheckLastDurationSeconds.With(labels).Set(duration.Seconds())
+	if passed {
+		RepositoryCheckTotal.With(prometheus.Labels{"repository": repo, "status": "success"}).Inc()
+		RepositoryCheckLastSuccessTimestamp.With(labels).Set(float64(time.Now().Unix()))
+		RepositoryCheckHealthy.With(labels).Set(1)
+		return
+	}
+	RepositoryCheckTotal.With(prometheus.Labels{"repository": repo, "status": "failure"}).Inc()
+	RepositoryCheckHealthy.With(labels).Set(0)
+}
+
+// RecordRepositoryCheckSkipped counts a check skipped because the repository was busy.
+func RecordRepositoryCheckSkipped(repo string) {
+	RepositoryCheckTotal.With(prometheus.Labels{"repository": repo, "status": "skipped"}).Inc()
+}
+
 // RecordManagedDatabases updates the managed database count for an engine.
 func RecordManagedDatabases(engine string, count int) {
 	ManagedDatabases.With(prometheus.Labels{"engine": engine}).Set(float64(count))
@@ -222,3 +506,57 @@ func RecordManagedDatabases(engine string, count int) {
 func RecordReconcile(engine, status string) {
 	ControllerReconcileTotal.With(prometheus.Labels{"engine": engine, "status": status}).Inc()
 }
+
+// RecordQueueDepth sets the number of pending jobs for a database.
+func RecordQueueDepth(engine, cluster, ns string, depth int) {
+	QueueDepth.With(prometheus.Labels{"engine": engine, "cluster": cluster, "namespace": ns}).Set(float64(depth))
+}
+
+// DeleteQueueDepth removes the queue depth gauge for a deregistered database.
+func DeleteQueueDepth(engine, cluster, ns string) {
+	QueueDepth.Delete(prometheus.Labels{"engine": engine, "cluster": cluster, "namespace": ns})
+}
+
+// RecordQueueWait observes how long a job waited before it started.
+func RecordQueueWait(engine, operation string, wait time.Duration) {
+	QueueWaitSeconds.With(prometheus.Labels{"engine": engine, "operation": operation}).Observe(wait.Seconds())
+}
+
+// RecordQueueCoalesced counts a trigger merged into an already-pending job.
+func RecordQueueCoalesced(engine, cluster, ns, operation string) {
+	QueueCoalescedTotal.With(prometheus.Labels{
+		"engine": engine, "cluster": cluster, "namespace": ns, "operation": operation,
+	}).Inc()
+}
+
+// RecordMissedRun counts a scheduled run missed during operator downtime.
+// action is "caught_up" or "skipped" (past the starting deadline).
+func RecordMissedRun(engine, cluster, ns, operation, action string) {
+	MissedRunsTotal.With(prometheus.Labels{
+		"engine": engine, "cluster": cluster, "namespace": ns, "operation": operation, "action": action,
+	}).Inc()
+}
+
+// RecordDeferred counts an operation deferred by a time window. window is
+// "blackout" or "maintenance".
+func RecordDeferred(engine, cluster, ns, operation, window string) {
+	DeferredTotal.With(prometheus.Labels{
+		"engine": engine, "cluster": cluster, "namespace": ns, "operation": operation, "window": window,
+	}).Inc()
+}
+
+// RecordPolicyConflicts sets the number of extra policies selecting a database.
+func RecordPolicyConflicts(engine, cluster, ns string, conflicts int) {
+	PolicyConflicts.With(prometheus.Labels{"engine": engine, "cluster": cluster, "namespace": ns}).Set(float64(conflicts))
+}
+
+// DeletePolicyConflicts removes the conflict gauge of a deregistered database.
+func DeletePolicyConflicts(engine, cluster, ns string) {
+	PolicyConflicts.Delete(prometheus.Labels{"engine": engine, "cluster": cluster, "namespace": ns})
+}
+
+// RecordNotification counts a notification delivery to a channel. status is
+// "success" or "failure".
+func RecordNotification(channel, status string) {
+	NotificationsTotal.With(prometheus.Labels{"channel": channel, "status": status}).Inc()
+}
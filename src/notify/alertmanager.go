@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// alertDuration is how long an alert stays firing. Notifications are one-off
+// events with no resolve signal, so each alert carries its own endsAt.
+const alertDuration = time.Hour
+
+// alertmanagerNotifier posts each notification as one alert to the
+// Alertmanager v2 API.
+type alertmanagerNotifier struct {
+	*poster
+	url string
+}
+
+// postableAlert is the Alertmanager v2 PostableAlert.
+type postableAlert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	StartsAt    time.Time         `json:"startsAt"`
+	EndsAt      time.Time         `json:"endsAt"`
+}
+
+// alertsURL appends the v2 alerts path to an Alertmanager base URL.
+func alertsURL(base string) string {
+	base = strings.TrimSuffix(base, "/")
+	if strings.HasSuffix(base, "/api/v2/alerts") {
+		return base
+	}
+	return base + "/api/v2/alerts"
+}
+
+func (a *alertmanagerNotifier) Notify(ctx context.Context, n *Notification) error {
+	labels := map[string]string{
+		"alertname": "Hasteward" + n.Reason,
+		"reason":    n.Reason,
+		"severity":  n.Severity,
+	}
+	if engine, ok := n.Event.Details["engine"].(string); ok {
+		labels["engine"] = engine
+	}
+	if r := n.Event.Resource; r != nil {
+		labels["namespace"] = r.Namespace
+		labels["cluster"] = r.Name
+	}
+
+	annotations := map[string]string{
+		"summary":     fmt.Sprintf("%s on %s", n.Reason, target(n)),
+		"description": n.Event.Message,
+	}
+	if n.Result != nil {
+		if data, err := json.Marshal(n.Result); err == nil {
+			annotations["result"] = string(data)
+		}
+	}
+
+	start := n.Event.Timestamp
+	return a.post(ctx, a.url, []postableAlert{{
+		Labels:      labels,
+		Annotations: annotations,
+		StartsAt:    start,
+		EndsAt:      start.Add(alertDuration),
+	}})
+}
@@ -0,0 +1,124 @@
+// Package notify delivers operator events to external endpoints: generic
+// JSON webhooks, Slack/Mattermost incoming webhooks and Alertmanager.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/PrPlanIT/HASteward/src/output/model"
+)
+
+// Channel types, matching NotificationChannel spec.type.
+const (
+	TypeWebhook      = "webhook"
+	TypeSlack        = "slack"
+	TypeAlertmanager = "alertmanager"
+)
+
+// Notification severities.
+const (
+	SeverityInfo    = "info"
+	SeverityWarning = "warning"
+)
+
+// DefaultTimeout bounds a single delivery when Config.Timeout is zero.
+const DefaultTimeout = 10 * time.Second
+
+// Notification is the payload delivered to a channel. Event describes what
+// happened to which database; Result, when set, is the operation's result
+// (e.g. *model.BackupResult or *model.RepairResult).
+type Notification struct {
+	Reason   string      `json:"reason"`
+	Severity string      `json:"severity"`
+	Event    model.Event `json:"event"`
+	Result   any         `json:"result,omitempty"`
+}
+
+// Notifier delivers notifications to one endpoint.
+type Notifier interface {
+	Notify(ctx context.Context, n *Notification) error
+}
+
+// Config describes one endpoint.
+type Config struct {
+	Type    string
+	URL     string
+	Headers map[string]string
+	Timeout time.Duration
+
+	// Slack only
+	Channel  string
+	Username string
+}
+
+// New returns the Notifier for cfg.Type.
+func New(cfg Config) (Notifier, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("notification URL is empty")
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	p := &poster{client: &http.Client{Timeout: timeout}, headers: cfg.Headers}
+
+	switch cfg.Type {
+	case TypeWebhook:
+		return &webhookNotifier{poster: p, url: cfg.URL}, nil
+	case TypeSlack:
+		return &slackNotifier{poster: p, url: cfg.URL, channel: cfg.Channel, username: cfg.Username}, nil
+	case TypeAlertmanager:
+		return &alertmanagerNotifier{poster: p, url: alertsURL(cfg.URL)}, nil
+	default:
+		return nil, fmt.Errorf("unknown notification type %q", cfg.Type)
+	}
+}
+
+// poster sends JSON bodies with the channel's headers.
+type poster struct {
+	client  *http.Client
+	headers map[string]string
+}
+
+// post marshals body and POSTs it, treating any non-2xx response as an error.
+func (p *poster) post(ctx context.Context, url string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range p.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s returned %s: %s", req.URL.Redacted(), resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// target formats the database a notification is about as
+// "engine namespace/name".
+func target(n *Notification) string {
+	engine, _ := n.Event.Details["engine"].(string)
+	if n.Event.Resource == nil {
+		return engine
+	}
+	return fmt.Sprintf("%s %s/%s", engine, n.Event.Resource.Namespace, n.Event.Resource.Name)
+}
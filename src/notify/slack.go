@@ -0,0 +1,58 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+)
+
+// slackNotifier posts to a Slack or Mattermost incoming webhook. Both accept
+// the same text-plus-attachments message.
+type slackNotifier struct {
+	*poster
+	url      string
+	channel  string
+	username string
+}
+
+type slackMessage struct {
+	Text        string            `json:"text"`
+	Channel     string            `json:"channel,omitempty"`
+	Username    string            `json:"username,omitempty"`
+	Attachments []slackAttachment `json:"attachments,omitempty"`
+}
+
+type slackAttachment struct {
+	Color    string       `json:"color"`
+	Fallback string       `json:"fallback"`
+	Text     string       `json:"text"`
+	Fields   []slackField `json:"fields,omitempty"`
+	Ts       int64        `json:"ts,omitempty"`
+}
+
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+func (s *slackNotifier) Notify(ctx context.Context, n *Notification) error {
+	color := "good"
+	if n.Severity == SeverityWarning {
+		color = "danger"
+	}
+	msg := slackMessage{
+		Text:     fmt.Sprintf("*%s* on %s", n.Reason, target(n)),
+		Channel:  s.channel,
+		Username: s.username,
+		Attachments: []slackAttachment{{
+			Color:    color,
+			Fallback: fmt.Sprintf("%s on %s: %s", n.Reason, target(n), n.Event.Message),
+			Text:     n.Event.Message,
+			Ts:       n.Event.Timestamp.Unix(),
+		}},
+	}
+	if policy, ok := n.Event.Details["policy"].(string); ok && policy != "" {
+		msg.Attachments[0].Fields = append(msg.Attachments[0].Fields, slackField{Title: "Policy", Value: policy, Short: true})
+	}
+	return s.post(ctx, s.url, msg)
+}
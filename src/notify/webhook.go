@@ -0,0 +1,13 @@
+package notify
+
+import "context"
+
+// webhookNotifier POSTs the Notification as JSON.
+type webhookNotifier struct {
+	*poster
+	url string
+}
+
+func (w *webhookNotifier) Notify(ctx context.Context, n *Notification) error {
+	return w.post(ctx, w.url, n)
+}
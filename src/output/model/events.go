@@ -12,6 +12,7 @@ const (
 	EventCheckFailed   = "check.failed"
 	EventStepStarted   = "step.started"
 	EventStepComplete  = "step.completed"
+	EventNotification  = "notification"
 )
 
 // Canonical phase names for dangerous commands.
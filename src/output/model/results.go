@@ -98,10 +98,11 @@ type RepairResult struct {
 
 // RestoreResult holds the outcome of a restore operation.
 type RestoreResult struct {
-	Engine     string        `json:"engine"`
-	Cluster    ObjectRef     `json:"cluster"`
-	SnapshotID string        `json:"snapshotId"`
-	Duration   time.Duration `json:"duration"`
+	Engine        string        `json:"engine"`
+	Cluster       ObjectRef     `json:"cluster"`
+	SnapshotID    string        `json:"snapshotId"`
+	Duration      time.Duration `json:"duration"`
+	BytesRestored int64         `json:"bytesRestored"`
 }
 
 // BootstrapDecision captures the eligibility analysis for a Galera bootstrap.
@@ -190,6 +191,8 @@ type RepositoryEntry struct {
 	SnapshotCount    int64  `json:"snapshotCount"`
 	TotalSize        string `json:"totalSize"`
 	DeduplicatedSize string `json:"deduplicatedSize"`
+	LastCheck        string `json:"lastCheck,omitempty"`
+	LastError        string `json:"lastError,omitempty"`
 }
 
 // GetStatusResult holds the output of "get status".
@@ -199,13 +202,138 @@ type GetStatusResult struct {
 
 // ClusterStatusEntry represents a managed database cluster in "get status" output.
 type ClusterStatusEntry struct {
-	Engine       string `json:"engine"`
-	Namespace    string `json:"namespace"`
-	Name         string `json:"name"`
-	Managed      string `json:"managed"`
-	TriageResult string `json:"triageResult"`
-	LastTriage   string `json:"lastTriage"`
-	LastBackup   string `json:"lastBackup"`
+	Engine        string   `json:"engine"`
+	Namespace     string   `json:"namespace"`
+	Name          string   `json:"name"`
+	Managed       string   `json:"managed"`
+	TriageResult  string   `json:"triageResult"`
+	LastTriage    string   `json:"lastTriage"`
+	LastBackup    string   `json:"lastBackup"`
+	Ready         string   `json:"ready,omitempty"`
+	LastRepair    string   `json:"lastRepair,omitempty"`
+	NextBackup    string   `json:"nextBackup,omitempty"`
+	RPO           string   `json:"rpo,omitempty"`
+	RPOViolations []string `json:"rpoViolations,omitempty"`
+}
+
+// GetTriageHistoryResult holds the output of "get triage-history".
+type GetTriageHistoryResult struct {
+	Databases []TriageHistoryEntry `json:"databases"`
+}
+
+// TriageHistoryEntry is the recorded triage history of one managed database,
+// newest first.
+type TriageHistoryEntry struct {
+	Engine    string `json:"engine"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Transitions counts result changes across the history; many
+	// transitions in a short history indicate a flapping cluster.
+	Transitions int                  `json:"transitions"`
+	History     []TriageHistoryPoint `json:"history"`
+}
+
+// TriageHistoryPoint is one recorded triage in "get triage-history" output.
+type TriageHistoryPoint struct {
+	Time         string                  `json:"time"`
+	Result       string                  `json:"result"`
+	ReadyCount   int                     `json:"readyCount"`
+	TotalCount   int                     `json:"totalCount"`
+	ClusterPhase string                  `json:"clusterPhase,omitempty"`
+	Instances    []TriageHistoryInstance `json:"instances,omitempty"`
+}
+
+// TriageHistoryInstance is one instance of a TriageHistoryPoint. Lag is WAL
+// bytes behind the most advanced instance (CNPG) or seqno lag (Galera).
+type TriageHistoryInstance struct {
+	Pod      string `json:"pod"`
+	Ready    bool   `json:"ready"`
+	Primary  bool   `json:"primary,omitempty"`
+	Position string `json:"position,omitempty"`
+	Lag      int64  `json:"lag"`
+	DiskPct  int    `json:"diskPct"`
+}
+
+// GetDatabasesResult holds the operator API's list of managed databases.
+type GetDatabasesResult struct {
+	Databases []DatabaseEntry `json:"databases"`
+}
+
+// DatabaseEntry is a database registered with the operator scheduler: its
+// effective schedules, next runs, queued jobs and last recorded results.
+type DatabaseEntry struct {
+	Engine         string   `json:"engine"`
+	Namespace      string   `json:"namespace"`
+	Name           string   `json:"name"`
+	Policy         string   `json:"policy,omitempty"`
+	Mode           string   `json:"mode"`
+	Repositories   []string `json:"repositories,omitempty"`
+	BackupSchedule string   `json:"backupSchedule,omitempty"`
+	TriageSchedule string   `json:"triageSchedule,omitempty"`
+	PruneSchedule  string   `json:"pruneSchedule,omitempty"`
+
+	NextRuns DatabaseNextRuns `json:"nextRuns"`
+	// Queued lists jobs waiting for the database's worker, e.g.
+	// "backup/local-backups".
+	Queued []string `json:"queued,omitempty"`
+
+	LastTriage        *DatabaseTriage  `json:"lastTriage,omitempty"`
+	LastBackups       []DatabaseBackup `json:"lastBackups,omitempty"`
+	LastRepair        *DatabaseRepair  `json:"lastRepair,omitempty"`
+	RepairCircuitOpen bool             `json:"repairCircuitOpen,omitempty"`
+	// RecentRepairs are the start times of auto-repairs within the
+	// database's repair limit window.
+	RecentRepairs []time.Time `json:"recentRepairs,omitempty"`
+}
+
+// DatabaseNextRuns holds the next fire time of each scheduled operation.
+type DatabaseNextRuns struct {
+	Backup *time.Time `json:"backup,omitempty"`
+	Triage *time.Time `json:"triage,omitempty"`
+	Prune  *time.Time `json:"prune,omitempty"`
+}
+
+// DatabaseTriage is the last scheduled triage of a database.
+type DatabaseTriage struct {
+	Time              time.Time `json:"time"`
+	Result            string    `json:"result"`
+	ReadyCount        int       `json:"readyCount"`
+	TotalCount        int       `json:"totalCount"`
+	ClusterPhase      string    `json:"clusterPhase,omitempty"`
+	AuthorityStatus   string    `json:"authorityStatus,omitempty"`
+	RecommendedDonor  string    `json:"recommendedDonor,omitempty"`
+	SplitBrainDetails []string  `json:"splitBrainDetails,omitempty"`
+	RPOViolations     []string  `json:"rpoViolations,omitempty"`
+}
+
+// DatabaseBackup is the last backup of a database to one repository.
+type DatabaseBackup struct {
+	Repository string    `json:"repository"`
+	Time       time.Time `json:"time"`
+	Result     string    `json:"result,omitempty"`
+	Duration   string    `json:"duration,omitempty"`
+	SnapshotID string    `json:"snapshotId,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// DatabaseRepair is the last repair of a database.
+type DatabaseRepair struct {
+	Time            time.Time `json:"time"`
+	Result          string    `json:"result"`
+	HealedInstances []string  `json:"healedInstances,omitempty"`
+	Duration        string    `json:"duration,omitempty"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// TriggerResult is the operator API's response to a triage, backup or repair
+// trigger. Jobs already pending for the database are not queued twice.
+type TriggerResult struct {
+	Engine    string   `json:"engine"`
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Operation string   `json:"operation"`
+	Queued    []string `json:"queued,omitempty"`
+	Coalesced []string `json:"coalesced,omitempty"`
 }
 
 // PruneResult holds the output of "prune backups".
@@ -34,7 +34,7 @@ func New(mode OutputMode, command string) *Printer {
 		Mode:    resolved,
 		Stdout:  os.Stdout,
 		Stderr:  os.Stderr,
-		RunID:   generateRunID(),
+		RunID:   NewRunID(),
 		Command: command,
 		Start:   time.Now(),
 	}
@@ -138,7 +138,8 @@ func (p *Printer) Diagnostic(format string, args ...any) {
 	fmt.Fprintf(p.Stderr, format+"\n", args...)
 }
 
-func generateRunID() string {
+// NewRunID returns a random identifier for one command run or API request.
+func NewRunID() string {
 	b := make([]byte, 8)
 	_, _ = rand.Read(b)
 	return hex.EncodeToString(b)
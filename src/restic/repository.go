@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 )
 
 // Init initializes a new restic repository. Returns nil if already initialized.
@@ -20,9 +21,20 @@ func (c *Client) Init(ctx context.Context) error {
 	return nil
 }
 
-// Check verifies repository integrity.
-func (c *Client) Check(ctx context.Context) error {
-	_, err := c.Run(ctx, "check")
+// Check verifies repository integrity. readDataSubset is passed to
+// --read-data-subset (e.g. "5%", "1/7", "500M") to additionally verify a
+// sample of pack data; empty checks structure only. retryLock is how long
+// restic waits for locks held by other processes (e.g. running backups)
+// before giving up; zero fails at once.
+func (c *Client) Check(ctx context.Context, readDataSubset string, retryLock time.Duration) error {
+	args := []string{"check"}
+	if retryLock > 0 {
+		args = append(args, "--retry-lock", retryLock.String())
+	}
+	if readDataSubset != "" {
+		args = append(args, "--read-data-subset", readDataSubset)
+	}
+	_, err := c.Run(ctx, args...)
 	return err
 }
 
@@ -32,15 +44,28 @@ func (c *Client) Unlock(ctx context.Context) error {
 	return err
 }
 
+// Stats modes accepted by restic stats --mode.
+const (
+	// StatsModeRestoreSize reports the logical size of all snapshots as if restored.
+	StatsModeRestoreSize = "restore-size"
+	// StatsModeRawData reports the deduplicated, stored size of referenced blobs.
+	StatsModeRawData = "raw-data"
+)
+
 // RepoStats holds repository statistics from restic stats.
 type RepoStats struct {
 	TotalSize      int64 `json:"total_size"`
 	TotalFileCount int   `json:"total_file_count"`
 }
 
-// Stats returns repository statistics.
-func (c *Client) Stats(ctx context.Context) (*RepoStats, error) {
-	out, err := c.Run(ctx, "stats", "--json")
+// Stats returns repository statistics for the given mode (StatsModeRestoreSize
+// or StatsModeRawData). An empty mode uses restic's default (restore-size).
+func (c *Client) Stats(ctx context.Context, mode string) (*RepoStats, error) {
+	args := []string{"stats", "--json"}
+	if mode != "" {
+		args = append(args, "--mode", mode)
+	}
+	out, err := c.Run(ctx, args...)
 	if err != nil {
 		return nil, err
 	}
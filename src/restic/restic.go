@@ -3,6 +3,7 @@ package restic
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -23,10 +24,12 @@ type Client struct {
 }
 
 // NewClient creates a new restic client.
-func NewClient(repository, password string) *Client {
+// env carries backend credentials (S3, B2, rest-server) and may be nil.
+func NewClient(repository, password string, env map[string]string) *Client {
 	return &Client{
 		Repository: repository,
 		Password:   password,
+		Env:        env,
 	}
 }
 
@@ -50,6 +53,17 @@ func (c *Client) buildEnv() []string {
 	return env
 }
 
+// lockFailedExitCode is restic's exit code when it cannot lock the
+// repository (restic 0.17+).
+const lockFailedExitCode = 11
+
+// IsLockFailure reports whether err is a restic command that failed because
+// another process holds a conflicting repository lock.
+func IsLockFailure(err error) bool {
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr) && exitErr.ExitCode() == lockFailedExitCode
+}
+
 // Run executes a restic command and returns stdout.
 func (c *Client) Run(ctx context.Context, args ...string) ([]byte, error) {
 	cmd := exec.CommandContext(ctx, c.binary(), args...)